@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -58,6 +59,12 @@ var podNamespaceEnvVar = "POD_NAMESPACE"
 // will be 12h.
 var updatePollingIntervalEnvVar = "UPDATE_POLLING_INTERVAL"
 
+// enableWebhooksEnvVar is the constant for env variable ENABLE_WEBHOOKS
+// which specifies whether the operator serves the Hawtio admission webhooks.
+// The webhooks are disabled by default since they require a serving
+// certificate and the webhook configurations to be installed.
+var enableWebhooksEnvVar = "ENABLE_WEBHOOKS"
+
 // Go build-time variables
 var (
 	ImageRepository                      string
@@ -137,16 +144,19 @@ func main() {
 	// Get update polling interval (Empty = 12h)
 	updatePollingInterval := getUpdateInterval()
 
+	// Get whether to serve the webhooks (Empty = false)
+	enableWebhooks := getEnableWebhooks()
+
 	flag.Parse()
 
-	err = operatorRun(watchNamespaces, podNamespace, updatePollingInterval, cfg)
+	err = operatorRun(watchNamespaces, podNamespace, updatePollingInterval, enableWebhooks, cfg)
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
 // operatorRun setup and run the operator
-func operatorRun(watchNamespaces string, podNamespace string, updatePollingInterval time.Duration, cfg *rest.Config) error {
+func operatorRun(watchNamespaces string, podNamespace string, updatePollingInterval time.Duration, enableWebhooks bool, cfg *rest.Config) error {
 	// Become the leader before proceeding
 	// Note: leader.Become uses POD_NAMESPACE env var implicitly
 	err := leader.Become(context.TODO(), "hawtio-lock")
@@ -179,6 +189,7 @@ func operatorRun(watchNamespaces string, podNamespace string, updatePollingInter
 		hawtiomgr.WithPodNamespace(podNamespace),
 		hawtiomgr.WithBuildVariables(bv),
 		hawtiomgr.WithUpdatePollingInterval(updatePollingInterval),
+		hawtiomgr.WithWebhooks(enableWebhooks),
 	)
 
 	if err != nil {
@@ -251,3 +262,18 @@ func getUpdateInterval() time.Duration {
 
 	return updatePollingInterval
 }
+
+func getEnableWebhooks() bool {
+	enableWebhooksStr, found := os.LookupEnv(enableWebhooksEnvVar)
+	if !found || enableWebhooksStr == "" {
+		return false
+	}
+
+	enableWebhooks, err := strconv.ParseBool(enableWebhooksStr)
+	if err != nil {
+		log.Error(err, "Invalid ENABLE_WEBHOOKS format, defaulting to false")
+		return false
+	}
+
+	return enableWebhooks
+}
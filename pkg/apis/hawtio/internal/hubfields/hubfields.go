@@ -0,0 +1,255 @@
+// Package hubfields preserves the fields of the v2 hub version of the Hawtio
+// API that the older versions cannot represent, so that conversions are lossless.
+package hubfields
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+// hubFields holds the fields of the v2 API that have no equivalent in the
+// older versions. They are stored in the hawtiov2.ConversionDataAnnotation annotation.
+type hubFields struct {
+	InternalSSL               *bool                               `json:"internalSSL,omitempty"`
+	ClientCertKeyAlgorithm    hawtiov2.HawtioKeyAlgorithm         `json:"clientCertKeyAlgorithm,omitempty"`
+	MasterBurstSize           string                              `json:"masterBurstSize,omitempty"`
+	Logging                   *hawtiov2.HawtioLogging             `json:"logging,omitempty"`
+	HealthChecks              *hawtiov2.HawtioHealthCheckPeriods  `json:"healthChecks,omitempty"`
+	Probes                    *hawtiov2.HawtioProbes              `json:"probes,omitempty"`
+	ContainerResources        *hawtiov2.HawtioContainerResources  `json:"containerResources,omitempty"`
+	NodeSelector              map[string]string                   `json:"nodeSelector,omitempty"`
+	Tolerations               []corev1.Toleration                 `json:"tolerations,omitempty"`
+	Affinity                  *corev1.Affinity                    `json:"affinity,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint   `json:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                              `json:"priorityClassName,omitempty"`
+	RuntimeClassName          *string                             `json:"runtimeClassName,omitempty"`
+	SecurityContext           *hawtiov2.HawtioSecurityContext     `json:"securityContext,omitempty"`
+	PodDisruptionBudget       *hawtiov2.HawtioPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	Autoscaling               *hawtiov2.HawtioAutoscaling         `json:"autoscaling,omitempty"`
+	Rollout                   *hawtiov2.HawtioRollout             `json:"rollout,omitempty"`
+	PodTemplate               *hawtiov2.HawtioPodTemplate         `json:"podTemplate,omitempty"`
+	Images                    *hawtiov2.HawtioImages              `json:"images,omitempty"`
+	Service                   *hawtiov2.HawtioService             `json:"service,omitempty"`
+	Access                    *hawtiov2.HawtioAccess              `json:"access,omitempty"`
+	NetworkPolicy             *hawtiov2.HawtioNetworkPolicy       `json:"networkPolicy,omitempty"`
+	RouteTermination          hawtiov2.HawtioRouteTermination     `json:"routeTermination,omitempty"`
+	RouteInsecurePolicy       string                              `json:"routeInsecurePolicy,omitempty"`
+	RoutePath                 string                              `json:"routePath,omitempty"`
+	RouteWildcardPolicy       string                              `json:"routeWildcardPolicy,omitempty"`
+	RouteLabels               map[string]string                   `json:"routeLabels,omitempty"`
+	RouteAnnotations          map[string]string                   `json:"routeAnnotations,omitempty"`
+	Ingress                   *hawtiov2.HawtioIngress             `json:"ingress,omitempty"`
+	HTTPRoute                 *hawtiov2.HawtioHTTPRoute           `json:"httpRoute,omitempty"`
+	Exposure                  *hawtiov2.HawtioExposure            `json:"exposure,omitempty"`
+	Hosts                     []string                            `json:"hosts,omitempty"`
+	ShowAppName               bool                                `json:"showAppName,omitempty"`
+	AppLogoDarkModeURL        string                              `json:"appLogoDarkModeUrl,omitempty"`
+	Description               string                              `json:"description,omitempty"`
+	ImgDarkModeSrc            string                              `json:"imgDarkModeSrc,omitempty"`
+	BackgroundImgSrc          string                              `json:"backgroundImgSrc,omitempty"`
+	GatewayImage              string                              `json:"gatewayImage,omitempty"`
+	URLs                      []string                            `json:"URLs,omitempty"`
+	ObservedGeneration        int64                               `json:"observedGeneration,omitempty"`
+	Conditions                []metav1.Condition                  `json:"conditions,omitempty"`
+}
+
+// Save stores the fields of the hub the older versions cannot represent
+// in the annotations of the converted object.
+func Save(hub *hawtiov2.Hawtio, spoke metav1.Object) error {
+	fields := hubFields{
+		InternalSSL:               hub.Spec.Auth.InternalSSL,
+		ClientCertKeyAlgorithm:    hub.Spec.Auth.ClientCertKeyAlgorithm,
+		MasterBurstSize:           hub.Spec.Nginx.MasterBurstSize,
+		NodeSelector:              hub.Spec.NodeSelector,
+		Tolerations:               hub.Spec.Tolerations,
+		Affinity:                  hub.Spec.Affinity,
+		TopologySpreadConstraints: hub.Spec.TopologySpreadConstraints,
+		PriorityClassName:         hub.Spec.PriorityClassName,
+		RuntimeClassName:          hub.Spec.RuntimeClassName,
+		RouteTermination:          hub.Spec.Route.Termination,
+		RouteInsecurePolicy:       hub.Spec.Route.InsecureEdgeTerminationPolicy,
+		RoutePath:                 hub.Spec.Route.Path,
+		RouteWildcardPolicy:       hub.Spec.Route.WildcardPolicy,
+		RouteLabels:               hub.Spec.Route.Labels,
+		RouteAnnotations:          hub.Spec.Route.Annotations,
+		Hosts:                     hub.Spec.Hosts,
+		ShowAppName:               hub.Spec.Config.Branding.ShowAppName,
+		AppLogoDarkModeURL:        hub.Spec.Config.Branding.AppLogoDarkModeURL,
+		Description:               hub.Spec.Config.About.Description,
+		ImgDarkModeSrc:            hub.Spec.Config.About.ImgDarkModeSrc,
+		BackgroundImgSrc:          hub.Spec.Config.About.BackgroundImgSrc,
+		GatewayImage:              hub.Status.GatewayImage,
+		URLs:                      hub.Status.URLs,
+		ObservedGeneration:        hub.Status.ObservedGeneration,
+		Conditions:                hub.Status.Conditions,
+	}
+	if hub.Spec.Logging != (hawtiov2.HawtioLogging{}) {
+		fields.Logging = &hub.Spec.Logging
+	}
+	if hub.Spec.HealthChecks != (hawtiov2.HawtioHealthCheckPeriods{}) {
+		fields.HealthChecks = &hub.Spec.HealthChecks
+	}
+	if hub.Spec.Probes != (hawtiov2.HawtioProbes{}) {
+		fields.Probes = &hub.Spec.Probes
+	}
+	if hub.Spec.ContainerResources != (hawtiov2.HawtioContainerResources{}) {
+		fields.ContainerResources = &hub.Spec.ContainerResources
+	}
+	if hub.Spec.SecurityContext != (hawtiov2.HawtioSecurityContext{}) {
+		fields.SecurityContext = &hub.Spec.SecurityContext
+	}
+	if hub.Spec.PodDisruptionBudget != (hawtiov2.HawtioPodDisruptionBudget{}) {
+		fields.PodDisruptionBudget = &hub.Spec.PodDisruptionBudget
+	}
+	if hub.Spec.Autoscaling != (hawtiov2.HawtioAutoscaling{}) {
+		fields.Autoscaling = &hub.Spec.Autoscaling
+	}
+	if hub.Spec.Rollout != (hawtiov2.HawtioRollout{}) {
+		fields.Rollout = &hub.Spec.Rollout
+	}
+	if !reflect.ValueOf(hub.Spec.PodTemplate).IsZero() {
+		fields.PodTemplate = &hub.Spec.PodTemplate
+	}
+	if !reflect.ValueOf(hub.Spec.Images).IsZero() {
+		fields.Images = &hub.Spec.Images
+	}
+	if !reflect.ValueOf(hub.Spec.Service).IsZero() {
+		fields.Service = &hub.Spec.Service
+	}
+	if !reflect.ValueOf(hub.Spec.Access).IsZero() {
+		fields.Access = &hub.Spec.Access
+	}
+	if !reflect.ValueOf(hub.Spec.NetworkPolicy).IsZero() {
+		fields.NetworkPolicy = &hub.Spec.NetworkPolicy
+	}
+	if !reflect.ValueOf(hub.Spec.Ingress).IsZero() {
+		fields.Ingress = &hub.Spec.Ingress
+	}
+	if !reflect.ValueOf(hub.Spec.HTTPRoute).IsZero() {
+		fields.HTTPRoute = &hub.Spec.HTTPRoute
+	}
+	if hub.Spec.Exposure != (hawtiov2.HawtioExposure{}) {
+		fields.Exposure = &hub.Spec.Exposure
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the %s annotation: %w", hawtiov2.ConversionDataAnnotation, err)
+	}
+
+	annotations := spoke.GetAnnotations()
+	if string(data) == "{}" {
+		delete(annotations, hawtiov2.ConversionDataAnnotation)
+		return nil
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[hawtiov2.ConversionDataAnnotation] = string(data)
+	spoke.SetAnnotations(annotations)
+
+	return nil
+}
+
+// Restore restores the fields stored by Save from the annotations of
+// the converted hub, and removes the annotation.
+func Restore(hub *hawtiov2.Hawtio) error {
+	data, ok := hub.Annotations[hawtiov2.ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+
+	fields := hubFields{}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal the %s annotation: %w", hawtiov2.ConversionDataAnnotation, err)
+	}
+
+	spec := &hub.Spec
+	spec.Auth.InternalSSL = fields.InternalSSL
+	spec.Auth.ClientCertKeyAlgorithm = fields.ClientCertKeyAlgorithm
+	spec.Nginx.MasterBurstSize = fields.MasterBurstSize
+	if fields.Logging != nil {
+		spec.Logging = *fields.Logging
+	}
+	if fields.HealthChecks != nil {
+		spec.HealthChecks = *fields.HealthChecks
+	}
+	if fields.Probes != nil {
+		spec.Probes = *fields.Probes
+	}
+	if fields.ContainerResources != nil {
+		spec.ContainerResources = *fields.ContainerResources
+	}
+	spec.NodeSelector = fields.NodeSelector
+	spec.Tolerations = fields.Tolerations
+	spec.Affinity = fields.Affinity
+	spec.TopologySpreadConstraints = fields.TopologySpreadConstraints
+	spec.PriorityClassName = fields.PriorityClassName
+	spec.RuntimeClassName = fields.RuntimeClassName
+	if fields.SecurityContext != nil {
+		spec.SecurityContext = *fields.SecurityContext
+	}
+	if fields.PodDisruptionBudget != nil {
+		spec.PodDisruptionBudget = *fields.PodDisruptionBudget
+	}
+	if fields.Autoscaling != nil {
+		spec.Autoscaling = *fields.Autoscaling
+	}
+	if fields.Rollout != nil {
+		spec.Rollout = *fields.Rollout
+	}
+	if fields.PodTemplate != nil {
+		spec.PodTemplate = *fields.PodTemplate
+	}
+	if fields.Images != nil {
+		spec.Images = *fields.Images
+	}
+	if fields.Service != nil {
+		spec.Service = *fields.Service
+	}
+	if fields.Access != nil {
+		spec.Access = *fields.Access
+	}
+	if fields.NetworkPolicy != nil {
+		spec.NetworkPolicy = *fields.NetworkPolicy
+	}
+	if fields.Ingress != nil {
+		spec.Ingress = *fields.Ingress
+	}
+	if fields.HTTPRoute != nil {
+		spec.HTTPRoute = *fields.HTTPRoute
+	}
+	if fields.Exposure != nil {
+		spec.Exposure = *fields.Exposure
+	}
+	spec.Route.Termination = fields.RouteTermination
+	spec.Route.InsecureEdgeTerminationPolicy = fields.RouteInsecurePolicy
+	spec.Route.Path = fields.RoutePath
+	spec.Route.WildcardPolicy = fields.RouteWildcardPolicy
+	spec.Route.Labels = fields.RouteLabels
+	spec.Route.Annotations = fields.RouteAnnotations
+	spec.Hosts = fields.Hosts
+	spec.Config.Branding.ShowAppName = fields.ShowAppName
+	spec.Config.Branding.AppLogoDarkModeURL = fields.AppLogoDarkModeURL
+	spec.Config.About.Description = fields.Description
+	spec.Config.About.ImgDarkModeSrc = fields.ImgDarkModeSrc
+	spec.Config.About.BackgroundImgSrc = fields.BackgroundImgSrc
+	hub.Status.GatewayImage = fields.GatewayImage
+	hub.Status.URLs = fields.URLs
+	hub.Status.ObservedGeneration = fields.ObservedGeneration
+	hub.Status.Conditions = fields.Conditions
+
+	delete(hub.Annotations, hawtiov2.ConversionDataAnnotation)
+	if len(hub.Annotations) == 0 {
+		hub.Annotations = nil
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/hawtio/hawtio-operator/pkg/apis/hawtio/internal/hubfields"
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+var _ conversion.Convertible = &Hawtio{}
+
+// ConvertTo converts this Hawtio to the v2 hub version
+func (src *Hawtio) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*hawtiov2.Hawtio)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	spec := &dst.Spec
+	spec.Type = hawtiov2.HawtioDeploymentType(src.Spec.Type)
+	spec.Replicas = src.Spec.Replicas
+	spec.MetadataPropagation = hawtiov2.HawtioMetadataPropagation{
+		Annotations: src.Spec.MetadataPropagation.Annotations,
+		Labels:      src.Spec.MetadataPropagation.Labels,
+	}
+	spec.RouteHostName = src.Spec.RouteHostName
+	spec.Route = hawtiov2.HawtioRoute{
+		CertSecret: src.Spec.Route.CertSecret,
+		CaCert:     src.Spec.Route.CaCert,
+	}
+	spec.ExternalRoutes = src.Spec.ExternalRoutes
+	spec.Version = src.Spec.Version
+	spec.Auth = hawtiov2.HawtioAuth{
+		ClientCertCommonName:       src.Spec.Auth.ClientCertCommonName,
+		ClientCertExpirationDate:   src.Spec.Auth.ClientCertExpirationDate,
+		ClientCertCheckSchedule:    src.Spec.Auth.ClientCertCheckSchedule,
+		ClientCertExpirationPeriod: src.Spec.Auth.ClientCertExpirationPeriod,
+	}
+	spec.Nginx = hawtiov2.HawtioNginx{
+		ClientBodyBufferSize:       src.Spec.Nginx.ClientBodyBufferSize,
+		ProxyBuffers:               src.Spec.Nginx.ProxyBuffers,
+		SubrequestOutputBufferSize: src.Spec.Nginx.SubrequestOutputBufferSize,
+	}
+	spec.RBAC = hawtiov2.HawtioRBAC{
+		ConfigMap:           src.Spec.RBAC.ConfigMap,
+		DisableRBACRegistry: src.Spec.RBAC.DisableRBACRegistry,
+	}
+	spec.Resources = src.Spec.Resources
+	spec.Config = hawtiov2.HawtioConfig{
+		About: hawtiov2.HawtioAbout{
+			Title:          src.Spec.Config.About.Title,
+			AdditionalInfo: src.Spec.Config.About.AdditionalInfo,
+			Copyright:      src.Spec.Config.About.Copyright,
+			ImgSrc:         src.Spec.Config.About.ImgSrc,
+		},
+		Branding: hawtiov2.HawtioBranding{
+			AppName:    src.Spec.Config.Branding.AppName,
+			AppLogoURL: src.Spec.Config.Branding.AppLogoURL,
+			CSS:        src.Spec.Config.Branding.CSS,
+			Favicon:    src.Spec.Config.Branding.Favicon,
+		},
+		Online: hawtiov2.HawtioOnline{
+			ProjectSelector: src.Spec.Config.Online.ProjectSelector,
+			ConsoleLink: hawtiov2.HawtioConsoleLink{
+				Text:              src.Spec.Config.Online.ConsoleLink.Text,
+				Section:           src.Spec.Config.Online.ConsoleLink.Section,
+				ImageRelativePath: src.Spec.Config.Online.ConsoleLink.ImageRelativePath,
+			},
+		},
+		DisabledRoutes: src.Spec.Config.DisabledRoutes,
+	}
+	for _, info := range src.Spec.Config.About.ProductInfos {
+		spec.Config.About.ProductInfos = append(spec.Config.About.ProductInfos, hawtiov2.HawtioProductInfo{
+			Name:  info.Name,
+			Value: info.Value,
+		})
+	}
+
+	dst.Status = hawtiov2.HawtioStatus{
+		Image:    src.Status.Image,
+		Phase:    hawtiov2.HawtioPhase(src.Status.Phase),
+		URL:      src.Status.URL,
+		Replicas: src.Status.Replicas,
+		Selector: src.Status.Selector,
+	}
+
+	// Restore the fields this version cannot represent
+	return hubfields.Restore(dst)
+}
+
+// ConvertFrom converts the v2 hub version to this Hawtio
+func (dst *Hawtio) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*hawtiov2.Hawtio)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	spec := &dst.Spec
+	spec.Type = HawtioDeploymentType(src.Spec.Type)
+	spec.Replicas = src.Spec.Replicas
+	spec.MetadataPropagation = HawtioMetadataPropagation{
+		Annotations: src.Spec.MetadataPropagation.Annotations,
+		Labels:      src.Spec.MetadataPropagation.Labels,
+	}
+	spec.RouteHostName = src.Spec.RouteHostName
+	spec.Route = HawtioRoute{
+		CertSecret: src.Spec.Route.CertSecret,
+		CaCert:     src.Spec.Route.CaCert,
+	}
+	spec.ExternalRoutes = src.Spec.ExternalRoutes
+	spec.Version = src.Spec.Version
+	spec.Auth = HawtioAuth{
+		ClientCertCommonName:       src.Spec.Auth.ClientCertCommonName,
+		ClientCertExpirationDate:   src.Spec.Auth.ClientCertExpirationDate,
+		ClientCertCheckSchedule:    src.Spec.Auth.ClientCertCheckSchedule,
+		ClientCertExpirationPeriod: src.Spec.Auth.ClientCertExpirationPeriod,
+	}
+	spec.Nginx = HawtioNginx{
+		ClientBodyBufferSize:       src.Spec.Nginx.ClientBodyBufferSize,
+		ProxyBuffers:               src.Spec.Nginx.ProxyBuffers,
+		SubrequestOutputBufferSize: src.Spec.Nginx.SubrequestOutputBufferSize,
+	}
+	spec.RBAC = HawtioRBAC{
+		ConfigMap:           src.Spec.RBAC.ConfigMap,
+		DisableRBACRegistry: src.Spec.RBAC.DisableRBACRegistry,
+	}
+	spec.Resources = src.Spec.Resources
+	spec.Config = HawtioConfig{
+		About: HawtioAbout{
+			Title:          src.Spec.Config.About.Title,
+			AdditionalInfo: src.Spec.Config.About.AdditionalInfo,
+			Copyright:      src.Spec.Config.About.Copyright,
+			ImgSrc:         src.Spec.Config.About.ImgSrc,
+		},
+		Branding: HawtioBranding{
+			AppName:    src.Spec.Config.Branding.AppName,
+			AppLogoURL: src.Spec.Config.Branding.AppLogoURL,
+			CSS:        src.Spec.Config.Branding.CSS,
+			Favicon:    src.Spec.Config.Branding.Favicon,
+		},
+		Online: HawtioOnline{
+			ProjectSelector: src.Spec.Config.Online.ProjectSelector,
+			ConsoleLink: HawtioConsoleLink{
+				Text:              src.Spec.Config.Online.ConsoleLink.Text,
+				Section:           src.Spec.Config.Online.ConsoleLink.Section,
+				ImageRelativePath: src.Spec.Config.Online.ConsoleLink.ImageRelativePath,
+			},
+		},
+		DisabledRoutes: src.Spec.Config.DisabledRoutes,
+	}
+	for _, info := range src.Spec.Config.About.ProductInfos {
+		spec.Config.About.ProductInfos = append(spec.Config.About.ProductInfos, HawtioProductInfo{
+			Name:  info.Name,
+			Value: info.Value,
+		})
+	}
+
+	dst.Status = HawtioStatus{
+		Image:    src.Status.Image,
+		Phase:    HawtioPhase(src.Status.Phase),
+		URL:      src.Status.URL,
+		Replicas: src.Status.Replicas,
+		Selector: src.Status.Selector,
+	}
+
+	// Preserve the fields this version cannot represent
+	return hubfields.Save(src, dst)
+}
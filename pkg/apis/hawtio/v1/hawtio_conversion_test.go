@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+func TestConvertRoundTripFromHub(t *testing.T) {
+	internalSSL := false
+	replicas := int32(2)
+	period := int32(30)
+	maxUnavailable := intstr.FromString("50%")
+	dualStack := corev1.IPFamilyPolicyPreferDualStack
+	affinityTimeout := int32(3600)
+
+	hub := &hawtiov2.Hawtio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "hawtio-online",
+			Namespace:   "hawtio-ns",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: hawtiov2.HawtioSpec{
+			Type:          hawtiov2.NamespaceHawtioDeploymentType,
+			Replicas:      &replicas,
+			RouteHostName: "hawtio.apps.example.com",
+			Auth: hawtiov2.HawtioAuth{
+				InternalSSL:            &internalSSL,
+				ClientCertCommonName:   "hawtio-online.hawtio.svc",
+				ClientCertKeyAlgorithm: hawtiov2.ECDSAP384HawtioKeyAlgorithm,
+			},
+			Nginx: hawtiov2.HawtioNginx{
+				ClientBodyBufferSize: "256k",
+				MasterBurstSize:      "5000",
+			},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+			ContainerResources: hawtiov2.HawtioContainerResources{
+				Gateway: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("500Mi")},
+				},
+			},
+			Config: hawtiov2.HawtioConfig{
+				About: hawtiov2.HawtioAbout{
+					Title:            "Hawtio",
+					ProductInfos:     []hawtiov2.HawtioProductInfo{{Name: "Hawtio", Value: "4.0"}},
+					Description:      "The Hawtio console",
+					ImgDarkModeSrc:   "img/dark.svg",
+					BackgroundImgSrc: "img/background.svg",
+				},
+				Branding: hawtiov2.HawtioBranding{
+					AppName:            "Hawtio",
+					ShowAppName:        true,
+					AppLogoURL:         "img/logo.svg",
+					AppLogoDarkModeURL: "img/logo-dark.svg",
+				},
+				Online: hawtiov2.HawtioOnline{
+					ConsoleLink: hawtiov2.HawtioConsoleLink{Text: "Hawtio"},
+				},
+			},
+			Logging: hawtiov2.HawtioLogging{
+				OnlineLogLevel:  "info",
+				GatewayLogLevel: "debug",
+			},
+			HealthChecks: hawtiov2.HawtioHealthCheckPeriods{
+				OnlineReadinessPeriod: &period,
+			},
+			Probes: hawtiov2.HawtioProbes{
+				Gateway: hawtiov2.HawtioContainerProbes{
+					Startup: &hawtiov2.HawtioProbe{FailureThreshold: &period},
+				},
+			},
+			NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+			Tolerations: []corev1.Toleration{
+				{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists},
+			},
+			PriorityClassName: "system-cluster-critical",
+			PodDisruptionBudget: hawtiov2.HawtioPodDisruptionBudget{
+				MaxUnavailable: &maxUnavailable,
+			},
+			Rollout: hawtiov2.HawtioRollout{
+				Strategy:        appsv1.RecreateDeploymentStrategyType,
+				MinReadySeconds: 10,
+			},
+			Service: hawtiov2.HawtioService{
+				Type:                          corev1.ServiceTypeLoadBalancer,
+				Annotations:                   map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+				LoadBalancerSourceRanges:      []string{"10.0.0.0/8"},
+				IPFamilies:                    []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				IPFamilyPolicy:                &dualStack,
+				SessionAffinity:               corev1.ServiceAffinityClientIP,
+				SessionAffinityTimeoutSeconds: &affinityTimeout,
+			},
+			Access:        hawtiov2.HawtioAccess{AllowedSourceRanges: []string{"10.0.0.0/8", "192.168.0.0/16"}},
+			NetworkPolicy: hawtiov2.HawtioNetworkPolicy{Enabled: true, IngressControllerNamespace: "traefik", APIServerCIDRs: []string{"10.0.0.1/32"}},
+			Route: hawtiov2.HawtioRoute{
+				CertSecret:                    corev1.LocalObjectReference{Name: "hawtio-route-tls"},
+				Termination:                   hawtiov2.EdgeHawtioRouteTermination,
+				InsecureEdgeTerminationPolicy: "Allow",
+				Path:                          "/hawtio",
+				WildcardPolicy:                "Subdomain",
+				Labels:                        map[string]string{"router": "internal"},
+				Annotations:                   map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+			},
+			Ingress: hawtiov2.HawtioIngress{
+				ClassName:   "nginx",
+				Hosts:       []string{"hawtio.example.com"},
+				Path:        "/hawtio",
+				TLSSecret:   corev1.LocalObjectReference{Name: "hawtio-tls"},
+				Annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "10m"},
+			},
+			HTTPRoute: hawtiov2.HawtioHTTPRoute{
+				ParentRef: hawtiov2.HawtioGatewayReference{Name: "public", Namespace: "gateways"},
+				Hostnames: []string{"hawtio.example.com"},
+			},
+			Exposure: hawtiov2.HawtioExposure{Type: hawtiov2.GatewayAPIHawtioExposureType},
+			Hosts:    []string{"console.example.com"},
+		},
+		Status: hawtiov2.HawtioStatus{
+			Image:              "quay.io/hawtio/online:latest",
+			GatewayImage:       "quay.io/hawtio/online-gateway:latest",
+			Phase:              hawtiov2.HawtioPhaseDeployed,
+			URL:                "https://hawtio.apps.example.com",
+			URLs:               []string{"https://hawtio.apps.example.com", "https://console.example.com"},
+			Replicas:           2,
+			ObservedGeneration: 3,
+			Conditions: []metav1.Condition{
+				{
+					Type:               hawtiov2.HawtioConditionReady,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 3,
+					Reason:             "Deployed",
+				},
+			},
+		},
+	}
+
+	spoke := &Hawtio{}
+	require.NoError(t, spoke.ConvertFrom(hub))
+
+	assert.Equal(t, HawtioDeploymentType("Namespace"), spoke.Spec.Type)
+	assert.Equal(t, "Hawtio", spoke.Spec.Config.About.Title)
+	assert.Equal(t, "quay.io/hawtio/online:latest", spoke.Status.Image)
+	assert.Contains(t, spoke.Annotations, hawtiov2.ConversionDataAnnotation)
+	assert.NotContains(t, hub.Annotations, hawtiov2.ConversionDataAnnotation)
+
+	restored := &hawtiov2.Hawtio{}
+	require.NoError(t, spoke.ConvertTo(restored))
+
+	assert.Equal(t, hub, restored)
+}
+
+func TestConvertRoundTripFromSpoke(t *testing.T) {
+	spoke := &Hawtio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hawtio-online",
+			Namespace: "hawtio-ns",
+		},
+		Spec: HawtioSpec{
+			Type:    ClusterHawtioDeploymentType,
+			Version: "1.0",
+			Auth: HawtioAuth{
+				ClientCertCheckSchedule: "* */12 * * *",
+			},
+			Config: HawtioConfig{
+				About: HawtioAbout{
+					AdditionalInfo: "Some info",
+				},
+			},
+		},
+		Status: HawtioStatus{
+			Phase: HawtioPhaseInitialized,
+		},
+	}
+
+	hub := &hawtiov2.Hawtio{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	assert.Empty(t, hub.Annotations)
+
+	restored := &Hawtio{}
+	require.NoError(t, restored.ConvertFrom(hub))
+
+	assert.Equal(t, spoke, restored)
+}
+
+func TestConvertToInvalidConversionData(t *testing.T) {
+	spoke := &Hawtio{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{hawtiov2.ConversionDataAnnotation: "{"},
+		},
+	}
+
+	err := spoke.ConvertTo(&hawtiov2.Hawtio{})
+	assert.ErrorContains(t, err, hawtiov2.ConversionDataAnnotation)
+}
@@ -0,0 +1,10 @@
+package v2
+
+// ConversionDataAnnotation stores, on the older Hawtio API versions, the
+// fields of the v2 API they cannot represent, so that conversions back to
+// v2 are lossless.
+const ConversionDataAnnotation = "hawt.io/conversion-data"
+
+// Hub marks v2 as the conversion hub, the older API versions being converted
+// to and from it.
+func (*Hawtio) Hub() {}
@@ -1,8 +1,10 @@
 package v2
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -24,6 +26,25 @@ const (
 	NamespaceHawtioDeploymentType HawtioDeploymentType = "Namespace"
 )
 
+// HawtioExposureType defines the possible ways of exposing the Hawtio service
+// +kubebuilder:validation:Enum=Route;Ingress;GatewayAPI;None
+type HawtioExposureType string
+
+const (
+	// RouteHawtioExposureType exposes the Hawtio service with an OpenShift route.
+	RouteHawtioExposureType HawtioExposureType = "Route"
+
+	// IngressHawtioExposureType exposes the Hawtio service with an ingress.
+	IngressHawtioExposureType HawtioExposureType = "Ingress"
+
+	// GatewayAPIHawtioExposureType exposes the Hawtio service with a Gateway API HTTPRoute.
+	GatewayAPIHawtioExposureType HawtioExposureType = "GatewayAPI"
+
+	// NoneHawtioExposureType does not expose the Hawtio service outside the cluster.
+	// The console is only reachable through the service, eg. with a port-forward.
+	NoneHawtioExposureType HawtioExposureType = "None"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=hawtios,scope=Namespaced,shortName=hwt;hio;hawt,categories=hawtio
@@ -53,6 +74,7 @@ type HawtioSpec struct {
 	// has access to.
 	// namespace: Hawtio is capable of discovering and managing
 	// applications within the deployment namespace.
+	// +kubebuilder:default=Cluster
 	Type HawtioDeploymentType `json:"type,omitempty"`
 	// Number of desired pods. This is a pointer to distinguish between explicit
 	// zero and not specified. Defaults to 1.
@@ -60,6 +82,15 @@ type HawtioSpec struct {
 	// The configuration for which metadata on Hawtio custom resources to propagate to
 	// generated resources such as deployments, pods, services, and routes.
 	MetadataPropagation HawtioMetadataPropagation `json:"metadataPropagation,omitempty"`
+	// The Hawtio service
+	// +optional
+	Service HawtioService `json:"service,omitempty"`
+	// The restrictions on the clients allowed to access the console
+	// +optional
+	Access HawtioAccess `json:"access,omitempty"`
+	// The NetworkPolicy of the Hawtio pods, for namespaces that deny traffic by default
+	// +optional
+	NetworkPolicy HawtioNetworkPolicy `json:"networkPolicy,omitempty"`
 	// The edge host name of the route that exposes the Hawtio service
 	// externally. If not specified, it is automatically generated and
 	// is of the form:
@@ -71,11 +102,29 @@ type HawtioSpec struct {
 	RouteHostName string `json:"routeHostName,omitempty"`
 	// Custom certificate configuration for the route
 	Route HawtioRoute `json:"route,omitempty"`
+	// The ingress that exposes the Hawtio service externally, on Kubernetes
+	// or when the Ingress exposure type is selected
+	// +optional
+	Ingress HawtioIngress `json:"ingress,omitempty"`
+	// The Gateway API HTTPRoute that exposes the Hawtio service externally
+	// through a parent Gateway. When a Gateway is referenced, and the cluster
+	// supports the Gateway API, the HTTPRoute replaces the ingress.
+	// +optional
+	HTTPRoute HawtioHTTPRoute `json:"httpRoute,omitempty"`
+	// How the Hawtio service is exposed outside the cluster
+	// +optional
+	Exposure HawtioExposure `json:"exposure,omitempty"`
+	// The additional host names, or aliases, the console is exposed at. A Route
+	// is created for each host name, on OpenShift, or an ingress rule is added,
+	// with the Ingress exposure type.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
 	// List of external route names that will be annotated by the operator to access the console using the routes
 	ExternalRoutes []string `json:"externalRoutes,omitempty"`
-	// The Hawtio console container image version.
-	// Deprecated: Remains for legacy purposes in respect of older
-	// operators (<1.0.0) still requiring it for their installs
+	// The version of the Hawtio console release, eg. 3.0, selecting the
+	// images and configuration of the console among the releases supported
+	// by the operator. Defaults to the release the operator is built for.
+	// The images specified in the images field take precedence.
 	Version string `json:"version,omitempty"`
 	// The authentication configuration
 	Auth HawtioAuth `json:"auth,omitempty"`
@@ -83,14 +132,219 @@ type HawtioSpec struct {
 	Nginx HawtioNginx `json:"nginx,omitempty"`
 	// The RBAC configuration
 	RBAC HawtioRBAC `json:"rbac,omitempty"`
-	// The Hawtio console compute resources
+	// The Hawtio console compute resources.
+	// Applies to the containers whose resources are not specified in containerResources.
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// The compute resources of each of the Hawtio console containers
+	// +optional
+	ContainerResources HawtioContainerResources `json:"containerResources,omitempty"`
 	// The Hawtio console configuration
 	Config HawtioConfig `json:"config,omitempty"`
 	// The Hawtio logging configuration
 	Logging HawtioLogging `json:"logging,omitempty"`
 	// The Hawtio health checking configuration
 	HealthChecks HawtioHealthCheckPeriods `json:"healthChecks,omitempty"`
+	// The probes of each of the Hawtio console containers
+	// +optional
+	Probes HawtioProbes `json:"probes,omitempty"`
+	// The node labels the Hawtio pods must be scheduled on
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The tolerations of the Hawtio pods, eg. to be scheduled on tainted infra nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// The scheduling constraints of the Hawtio pods.
+	// When not specified and more than one replica is requested, the pods
+	// are preferably scheduled on different nodes.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// How the Hawtio pods are spread across the topology domains of the cluster
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// The name of the priority class of the Hawtio pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// The name of the runtime class used to run the Hawtio pods
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// The security settings of the Hawtio pods and containers
+	// +optional
+	SecurityContext HawtioSecurityContext `json:"securityContext,omitempty"`
+	// The disruption budget of the Hawtio pods, only created when more than one replica is requested
+	// +optional
+	PodDisruptionBudget HawtioPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+	// The horizontal autoscaling of the Hawtio pods
+	// +optional
+	Autoscaling HawtioAutoscaling `json:"autoscaling,omitempty"`
+	// The rollout of the Hawtio deployment
+	// +optional
+	Rollout HawtioRollout `json:"rollout,omitempty"`
+	// Additions to the pod template generated by the operator
+	// +optional
+	PodTemplate HawtioPodTemplate `json:"podTemplate,omitempty"`
+	// The container images of the Hawtio console, overriding those of the operator
+	// +optional
+	Images HawtioImages `json:"images,omitempty"`
+}
+
+// The container images of the Hawtio console
+type HawtioImages struct {
+	// The online (nginx) container image
+	// +optional
+	Online HawtioImage `json:"online,omitempty"`
+	// The gateway container image
+	// +optional
+	Gateway HawtioImage `json:"gateway,omitempty"`
+	// The secrets used to pull the images from private registries
+	// +optional
+	PullSecrets []corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+}
+
+// A container image of the Hawtio console. An image whose repository, tag or digest
+// is specified is pinned, and is not updated by the operator update poller.
+type HawtioImage struct {
+	// The image repository, eg. quay.io/hawtio/online.
+	// Defaults to the repository configured for the operator.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+	// The image tag. Defaults to the version supported by the operator.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+	// The image digest, eg. sha256:..., that takes precedence over the tag
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+:[a-f0-9]{32,}$`
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// The image pull policy. Defaults to the pull policy configured for the operator.
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+// Additions to the pod template of the Hawtio deployment. They are merged into
+// the generated pod template, and cannot replace the volumes, volume mounts,
+// environment variables and containers managed by the operator.
+type HawtioPodTemplate struct {
+	// Additions to the online (nginx) container
+	// +optional
+	Online HawtioContainerTemplate `json:"online,omitempty"`
+	// Additions to the gateway container
+	// +optional
+	Gateway HawtioContainerTemplate `json:"gateway,omitempty"`
+	// Extra volumes of the Hawtio pods, that can be mounted with the volumeMounts
+	// field of the online and gateway containers, or by the extra containers
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// Extra init containers of the Hawtio pods
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+	// Extra sidecar containers of the Hawtio pods
+	// +optional
+	Containers []corev1.Container `json:"containers,omitempty"`
+}
+
+// Additions to a Hawtio container
+type HawtioContainerTemplate struct {
+	// Extra environment variables of the container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Extra sources of environment variables of the container
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// Extra volume mounts of the container
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// The horizontal autoscaling of the Hawtio pods. When enabled, a HorizontalPodAutoscaler
+// scales the Hawtio custom resource, through its scale subresource, between the minimum
+// and maximum number of replicas, and the replicas field is managed by the autoscaler.
+type HawtioAutoscaling struct {
+	// Whether the Hawtio pods are autoscaled. Defaults to `false`.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// The lower limit of the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// The upper limit of the number of replicas. Required when autoscaling is enabled.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// The target average CPU utilization, as a percentage of the requested CPU.
+	// Defaults to 80 if no target is specified.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// The target average memory utilization, as a percentage of the requested memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// The rollout of the Hawtio deployment, when its pod template changes, eg. on
+// configuration or image updates
+type HawtioRollout struct {
+	// The strategy used to replace the Hawtio pods, either RollingUpdate or Recreate.
+	// Recreate terminates the pods before creating the new ones, eg. so that
+	// a single-replica console never runs two versions side by side.
+	// Defaults to RollingUpdate.
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+	// +optional
+	Strategy appsv1.DeploymentStrategyType `json:"strategy,omitempty"`
+	// The number, or percentage, of pods that can be created above the desired
+	// replicas during a rolling update. Defaults to 25%.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// The number, or percentage, of pods that can be unavailable during a rolling
+	// update. Defaults to 25%.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// The number of seconds a new pod must be ready, without any of its containers
+	// crashing, to be considered available. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// The number of seconds the rollout can take to make progress before the
+	// console is considered failed. Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// The number of old replica sets kept to allow rolling back. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+}
+
+// The disruption budget of the Hawtio pods, eg. during node drains.
+// At most one of minAvailable and maxUnavailable can be specified.
+// Defaults to a maxUnavailable of 1.
+type HawtioPodDisruptionBudget struct {
+	// The number, or percentage, of Hawtio pods that must remain available
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// The number, or percentage, of Hawtio pods that can be unavailable
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// The security settings of the Hawtio pods and containers.
+// By default, they comply with the restricted Pod Security Standard.
+type HawtioSecurityContext struct {
+	// The security context of the Hawtio pods, replacing the default one.
+	// The default one runs the pods as non-root with the RuntimeDefault seccomp profile,
+	// and, on Kubernetes only, as the non-root user of the Hawtio images. On OpenShift,
+	// the user and group are assigned by the security context constraints.
+	// +optional
+	Pod *corev1.PodSecurityContext `json:"pod,omitempty"`
+	// The security context of the Hawtio containers, replacing the default one.
+	// The default one disallows privilege escalation and drops all the capabilities.
+	// +optional
+	Container *corev1.SecurityContext `json:"container,omitempty"`
+	// Mount the root filesystem of the containers as read-only. The paths the
+	// containers write to, eg. the nginx temporary and cache directories,
+	// are mounted as emptyDir volumes. Defaults to `false`.
+	// +optional
+	ReadOnlyRootFilesystem bool `json:"readOnlyRootFilesystem,omitempty"`
 }
 
 // The configuration for which metadata on Hawtio custom resources to propagate to
@@ -102,11 +356,195 @@ type HawtioMetadataPropagation struct {
 	Labels []string `json:"labels,omitempty"`
 }
 
+// The Hawtio service, that the route, ingress or HTTPRoute forward the traffic to
+type HawtioService struct {
+	// The type of the service, either ClusterIP, NodePort or LoadBalancer.
+	// Defaults to ClusterIP.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// Annotations added to the service, eg. to configure the cloud load balancer.
+	// They take precedence over the annotations set by the operator.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// The client IP ranges, in CIDR notation, allowed to access the load balancer.
+	// Only supported with the LoadBalancer type.
+	// +optional
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// The IP families of the service, eg. IPv4, IPv6 or both for dual-stack.
+	// Defaults to the IP family of the cluster.
+	// +kubebuilder:validation:MaxItems=2
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+	// The IP family policy of the service, either SingleStack, PreferDualStack
+	// or RequireDualStack. Defaults to SingleStack.
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// The session affinity of the service, either None or ClientIP, that routes
+	// the requests of a client to the same pod, eg. so that the form authentication
+	// session of a console with multiple replicas is preserved. Defaults to None.
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +optional
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+	// The maximum session sticky time, in seconds, of the ClientIP session affinity.
+	// Defaults to 10800, ie. 3 hours.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+}
+
+// The restrictions on the clients allowed to access the console, enforced by
+// the route, the ingress or the load balancer service the console is exposed with
+type HawtioAccess struct {
+	// The client IP ranges, in CIDR notation, allowed to access the console.
+	// They are set on the route, the NGINX ingress, and the service of the
+	// LoadBalancer type, unless its load balancer source ranges are specified.
+	// They are not supported with the GatewayAPI exposure type.
+	// If not specified, the console can be accessed from any address.
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
+}
+
+// The NetworkPolicy of the Hawtio pods. It allows the traffic to the pods from
+// the means the console is exposed with, and the traffic from the pods to the
+// cluster DNS, the API server, and the pods of the applications the console
+// connects to, either in the namespace of the Hawtio CR, or in all the namespaces
+// for the Cluster deployment type.
+type HawtioNetworkPolicy struct {
+	// Whether the operator manages the NetworkPolicy of the Hawtio pods.
+	// Defaults to false.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// The namespace of the ingress controller, or Gateway, pods the console is
+	// reached through. Defaults to ingress-nginx with the Ingress exposure on
+	// Kubernetes, and to the namespace of the Gateway with the GatewayAPI exposure.
+	// The traffic from the ingress routers is always allowed on OpenShift.
+	// +optional
+	IngressControllerNamespace string `json:"ingressControllerNamespace,omitempty"`
+	// The IP ranges, in CIDR notation, of the API server endpoints, eg. those of
+	// the kubernetes Service in the default namespace. The API server usually runs
+	// outside the pod network, so that it cannot be selected by namespace. If not
+	// specified, the traffic on the 443 and 6443 ports is allowed to any address.
+	// +optional
+	APIServerCIDRs []string `json:"apiServerCIDRs,omitempty"`
+}
+
 type HawtioRoute struct {
 	// Name of the TLS secret with the custom certificate used for the route TLS termination
 	CertSecret corev1.LocalObjectReference `json:"certSecret,omitempty"`
 	// Ca certificate secret key selector
 	CaCert corev1.SecretKeySelector `json:"caCert,omitempty"`
+	// The TLS termination of the route, either edge, reencrypt or passthrough.
+	// With edge termination, the router forwards plain HTTP, so that the Hawtio
+	// service does not use SSL. With passthrough termination, the serving
+	// certificate of the Hawtio service is presented to the browsers.
+	// Defaults to reencrypt.
+	// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+	// +optional
+	Termination HawtioRouteTermination `json:"termination,omitempty"`
+	// The policy for the insecure HTTP traffic, either None, Allow or Redirect.
+	// Allow is only supported with edge termination. Defaults to Redirect.
+	// +kubebuilder:validation:Enum=None;Allow;Redirect
+	// +optional
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+	// The path the console is served at. Not supported with passthrough termination.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// The wildcard policy of the route, either None or Subdomain, that routes
+	// all the hosts of the subdomain of the route host name. Defaults to None.
+	// Note that the operator recreates the route if the policy changes.
+	// +kubebuilder:validation:Enum=None;Subdomain
+	// +optional
+	WildcardPolicy string `json:"wildcardPolicy,omitempty"`
+	// Labels added to the route, eg. to select the router shard admitting it
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to the route, eg. the haproxy.router.openshift.io ones
+	// that configure the router timeout or IP allowlist.
+	// They take precedence over the annotations set by the operator.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// HawtioRouteTermination defines the possible TLS terminations of the route
+type HawtioRouteTermination string
+
+const (
+	// EdgeHawtioRouteTermination terminates TLS at the router.
+	EdgeHawtioRouteTermination HawtioRouteTermination = "edge"
+
+	// ReencryptHawtioRouteTermination terminates TLS at the router, that
+	// re-encrypts the traffic to the Hawtio service.
+	ReencryptHawtioRouteTermination HawtioRouteTermination = "reencrypt"
+
+	// PassthroughHawtioRouteTermination passes the TLS traffic through to the Hawtio service.
+	PassthroughHawtioRouteTermination HawtioRouteTermination = "passthrough"
+)
+
+// The ingress that exposes the Hawtio service
+type HawtioIngress struct {
+	// The name of the IngressClass of the ingress controller, eg. nginx.
+	// Defaults to the default IngressClass of the cluster.
+	// The annotations of the NGINX ingress controller that re-encrypt the
+	// traffic to the Hawtio pods, and serve the console at the ingress path,
+	// are only set when no class, or the nginx class, is specified.
+	// +optional
+	ClassName string `json:"className,omitempty"`
+	// The host names the console is served at. If not specified, the ingress
+	// matches any host.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+	// The path the console is served at. Defaults to /.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Name of the TLS secret with the certificate presented by the ingress
+	// controller for the hosts. Defaults to the serving certificate secret of
+	// the Hawtio service.
+	// +optional
+	TLSSecret corev1.LocalObjectReference `json:"tlsSecret,omitempty"`
+	// Annotations added to the ingress, eg. to configure the ingress controller.
+	// They take precedence over the annotations set by the operator.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// The Gateway API HTTPRoute that exposes the Hawtio service.
+// When the Hawtio service uses SSL, a BackendTLSPolicy is also created, so that
+// the Gateway re-encrypts the traffic to the Hawtio pods.
+type HawtioHTTPRoute struct {
+	// The Gateway the HTTPRoute is attached to
+	// +optional
+	ParentRef HawtioGatewayReference `json:"parentRef,omitempty"`
+	// The host names of the HTTPRoute. If not specified, those of the
+	// Gateway listeners apply.
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// The exposure of the Hawtio service outside the cluster.
+// The resources of the other exposure types are deleted.
+type HawtioExposure struct {
+	// The resource exposing the Hawtio service, either Route, Ingress,
+	// GatewayAPI or None. Defaults to Route on OpenShift, to GatewayAPI when
+	// a parent Gateway is referenced by the httpRoute field and the cluster
+	// supports the Gateway API, and to Ingress otherwise.
+	// +optional
+	Type HawtioExposureType `json:"type,omitempty"`
+}
+
+// A reference to a Gateway
+type HawtioGatewayReference struct {
+	// The name of the Gateway
+	Name string `json:"name,omitempty"`
+	// The namespace of the Gateway. Defaults to the namespace of the Hawtio CR,
+	// otherwise the Gateway must allow routes from that namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// The name of the Gateway listener the HTTPRoute is attached to.
+	// If not specified, it is attached to all the compatible listeners.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 // HawtioAuth The authentication configuration
@@ -129,8 +567,34 @@ type HawtioAuth struct {
 	// The duration in hours before the expiration date, during which the certification can be rotated.
 	// The default is set to 24 hours.
 	ClientCertExpirationPeriod int `json:"clientCertExpirationPeriod,omitempty"`
+	// The algorithm of the private key of the generated certificates, either
+	// RSA-2048, RSA-3072, RSA-4096, ECDSA-P256 or ECDSA-P384. Defaults to RSA-2048.
+	// The certificates are regenerated when it changes.
+	// +kubebuilder:validation:Enum=RSA-2048;RSA-3072;RSA-4096;ECDSA-P256;ECDSA-P384
+	// +optional
+	ClientCertKeyAlgorithm HawtioKeyAlgorithm `json:"clientCertKeyAlgorithm,omitempty"`
 }
 
+// HawtioKeyAlgorithm defines the possible algorithms of the private key of the generated certificates
+type HawtioKeyAlgorithm string
+
+const (
+	// RSA2048HawtioKeyAlgorithm generates a 2048-bit RSA key.
+	RSA2048HawtioKeyAlgorithm HawtioKeyAlgorithm = "RSA-2048"
+
+	// RSA3072HawtioKeyAlgorithm generates a 3072-bit RSA key.
+	RSA3072HawtioKeyAlgorithm HawtioKeyAlgorithm = "RSA-3072"
+
+	// RSA4096HawtioKeyAlgorithm generates a 4096-bit RSA key.
+	RSA4096HawtioKeyAlgorithm HawtioKeyAlgorithm = "RSA-4096"
+
+	// ECDSAP256HawtioKeyAlgorithm generates an ECDSA key on the P-256 curve.
+	ECDSAP256HawtioKeyAlgorithm HawtioKeyAlgorithm = "ECDSA-P256"
+
+	// ECDSAP384HawtioKeyAlgorithm generates an ECDSA key on the P-384 curve.
+	ECDSAP384HawtioKeyAlgorithm HawtioKeyAlgorithm = "ECDSA-P384"
+)
+
 // The Nginx runtime configuration
 type HawtioNginx struct {
 	// The buffer size for reading client request body. Defaults to `256k`.
@@ -149,6 +613,16 @@ type HawtioNginx struct {
 	MasterBurstSize string `json:"masterBurstSize,omitempty"`
 }
 
+// The compute resources of the Hawtio console containers
+type HawtioContainerResources struct {
+	// The compute resources of the online (nginx) container. Defaults to the resources field.
+	// +optional
+	Online *corev1.ResourceRequirements `json:"online,omitempty"`
+	// The compute resources of the gateway container. Defaults to the resources field.
+	// +optional
+	Gateway *corev1.ResourceRequirements `json:"gateway,omitempty"`
+}
+
 // The RBAC configuration
 type HawtioRBAC struct {
 	// The name of the ConfigMap that contains the ACL definition.
@@ -167,10 +641,22 @@ type HawtioStatus struct {
 	Phase HawtioPhase `json:"phase,omitempty"`
 	// The Hawtio console route URL
 	URL string `json:"URL,omitempty"`
+	// All the URLs the Hawtio console is exposed at, including those of the
+	// additional host names
+	URLs []string `json:"URLs,omitempty"`
 	// The actual number of pods
 	Replicas int32 `json:"replicas,omitempty"`
 	// The label selector for the Hawtio pods
 	Selector string `json:"selector,omitempty"`
+	// The generation of the Hawtio CR most recently observed by the operator
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// The latest available observations of the Hawtio console state
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // The Hawtio deployment phase
@@ -186,6 +672,36 @@ const (
 	HawtioPhaseFailed HawtioPhase = "Failed"
 )
 
+// The condition types reported in the Hawtio status
+const (
+	// HawtioConditionReady indicates the console is deployed and serving requests
+	HawtioConditionReady = "Ready"
+	// HawtioConditionProgressing indicates the console deployment is rolling out
+	HawtioConditionProgressing = "Progressing"
+	// HawtioConditionDegraded indicates the operator failed to reconcile the console
+	HawtioConditionDegraded = "Degraded"
+	// HawtioConditionRBACConfigValid indicates the RBAC ConfigMap, if any, is present and valid
+	HawtioConditionRBACConfigValid = "RBACConfigValid"
+	// HawtioConditionVersionSupported indicates the version, if any, is supported by the operator
+	HawtioConditionVersionSupported = "VersionSupported"
+	// HawtioConditionCertificatesValid indicates the certificates required by the console are valid
+	HawtioConditionCertificatesValid = "CertificatesValid"
+	// HawtioConditionRouteAdmitted indicates the OpenShift route has been admitted by a router
+	HawtioConditionRouteAdmitted = "RouteAdmitted"
+	// HawtioConditionIngressReady indicates the ingress has been assigned an address
+	HawtioConditionIngressReady = "IngressReady"
+	// HawtioConditionHTTPRouteAccepted indicates the Gateway API HTTPRoute has been accepted by its parent Gateway
+	HawtioConditionHTTPRouteAccepted = "HTTPRouteAccepted"
+	// HawtioConditionAccessRestricted indicates the allowed source ranges, if any, are enforced on all the means the console is exposed with
+	HawtioConditionAccessRestricted = "AccessRestricted"
+	// HawtioConditionPodTemplateApplied indicates the additions of the pod template, if any, are all applied to the deployment
+	HawtioConditionPodTemplateApplied = "PodTemplateApplied"
+	// HawtioConditionOAuthClientReady indicates the OpenShift OAuth client is configured
+	HawtioConditionOAuthClientReady = "OAuthClientReady"
+	// HawtioConditionConsoleLinkReady indicates the OpenShift console link is configured
+	HawtioConditionConsoleLinkReady = "ConsoleLinkReady"
+)
+
 // +kubebuilder:object:root=true
 // HawtioList contains a list of Hawtio
 type HawtioList struct {
@@ -299,6 +815,58 @@ type HawtioHealthCheckPeriods struct {
 	GatewayLivenessPeriod *int32 `json:"gatewayLivenessPeriod,omitempty"`
 }
 
+// The probes of the Hawtio console containers
+type HawtioProbes struct {
+	// The probes of the online (nginx) container
+	// +optional
+	Online HawtioContainerProbes `json:"online,omitempty"`
+	// The probes of the gateway container
+	// +optional
+	Gateway HawtioContainerProbes `json:"gateway,omitempty"`
+}
+
+// The probes of a Hawtio console container
+type HawtioContainerProbes struct {
+	// The readiness probe settings
+	// +optional
+	Readiness HawtioProbe `json:"readiness,omitempty"`
+	// The liveness probe settings
+	// +optional
+	Liveness HawtioProbe `json:"liveness,omitempty"`
+	// The startup probe settings. The container has a startup probe only when specified,
+	// that holds off the readiness and liveness probes until the container has started.
+	// Defaults to a period of 10 seconds and a failure threshold of 30.
+	// +optional
+	Startup *HawtioProbe `json:"startup,omitempty"`
+}
+
+// The settings of a probe. The unset settings keep their defaults.
+type HawtioProbe struct {
+	// The number of seconds after the container has started before the probe is initiated
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// The number of seconds after which the probe times out. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// The period, in seconds, between the probe checks.
+	// Takes precedence over the periods of the healthChecks field.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// The minimum consecutive successes for the probe to be considered successful after
+	// having failed. Must be 1 for the liveness and startup probes. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
+	// The minimum consecutive failures for the probe to be considered failed
+	// after having succeeded. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
 // Configure the logging options for the servers
 type HawtioLogging struct {
 	// Configure online log level {emerg|alert|crit|error|warn|notice|info}
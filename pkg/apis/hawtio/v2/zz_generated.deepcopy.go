@@ -5,7 +5,10 @@
 package v2
 
 import (
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -14,7 +17,7 @@ func (in *Hawtio) DeepCopyInto(out *Hawtio) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hawtio.
@@ -55,6 +58,26 @@ func (in *HawtioAbout) DeepCopy() *HawtioAbout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioAccess) DeepCopyInto(out *HawtioAccess) {
+	*out = *in
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioAccess.
+func (in *HawtioAccess) DeepCopy() *HawtioAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioAuth) DeepCopyInto(out *HawtioAuth) {
 	*out = *in
@@ -79,6 +102,36 @@ func (in *HawtioAuth) DeepCopy() *HawtioAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioAutoscaling) DeepCopyInto(out *HawtioAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioAutoscaling.
+func (in *HawtioAutoscaling) DeepCopy() *HawtioAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioBranding) DeepCopyInto(out *HawtioBranding) {
 	*out = *in
@@ -132,6 +185,140 @@ func (in *HawtioConsoleLink) DeepCopy() *HawtioConsoleLink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioContainerProbes) DeepCopyInto(out *HawtioContainerProbes) {
+	*out = *in
+	in.Readiness.DeepCopyInto(&out.Readiness)
+	in.Liveness.DeepCopyInto(&out.Liveness)
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(HawtioProbe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioContainerProbes.
+func (in *HawtioContainerProbes) DeepCopy() *HawtioContainerProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioContainerProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioContainerResources) DeepCopyInto(out *HawtioContainerResources) {
+	*out = *in
+	if in.Online != nil {
+		in, out := &in.Online, &out.Online
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioContainerResources.
+func (in *HawtioContainerResources) DeepCopy() *HawtioContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioContainerTemplate) DeepCopyInto(out *HawtioContainerTemplate) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioContainerTemplate.
+func (in *HawtioContainerTemplate) DeepCopy() *HawtioContainerTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioContainerTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioExposure) DeepCopyInto(out *HawtioExposure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioExposure.
+func (in *HawtioExposure) DeepCopy() *HawtioExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioGatewayReference) DeepCopyInto(out *HawtioGatewayReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioGatewayReference.
+func (in *HawtioGatewayReference) DeepCopy() *HawtioGatewayReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioGatewayReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioHTTPRoute) DeepCopyInto(out *HawtioHTTPRoute) {
+	*out = *in
+	out.ParentRef = in.ParentRef
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioHTTPRoute.
+func (in *HawtioHTTPRoute) DeepCopy() *HawtioHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioHealthCheckPeriods) DeepCopyInto(out *HawtioHealthCheckPeriods) {
 	*out = *in
@@ -167,6 +354,71 @@ func (in *HawtioHealthCheckPeriods) DeepCopy() *HawtioHealthCheckPeriods {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioImage) DeepCopyInto(out *HawtioImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioImage.
+func (in *HawtioImage) DeepCopy() *HawtioImage {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioImages) DeepCopyInto(out *HawtioImages) {
+	*out = *in
+	out.Online = in.Online
+	out.Gateway = in.Gateway
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioImages.
+func (in *HawtioImages) DeepCopy() *HawtioImages {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioIngress) DeepCopyInto(out *HawtioIngress) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.TLSSecret = in.TLSSecret
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioIngress.
+func (in *HawtioIngress) DeepCopy() *HawtioIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioList) DeepCopyInto(out *HawtioList) {
 	*out = *in
@@ -239,6 +491,26 @@ func (in *HawtioMetadataPropagation) DeepCopy() *HawtioMetadataPropagation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioNetworkPolicy) DeepCopyInto(out *HawtioNetworkPolicy) {
+	*out = *in
+	if in.APIServerCIDRs != nil {
+		in, out := &in.APIServerCIDRs, &out.APIServerCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioNetworkPolicy.
+func (in *HawtioNetworkPolicy) DeepCopy() *HawtioNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioNginx) DeepCopyInto(out *HawtioNginx) {
 	*out = *in
@@ -270,6 +542,126 @@ func (in *HawtioOnline) DeepCopy() *HawtioOnline {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioPodDisruptionBudget) DeepCopyInto(out *HawtioPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioPodDisruptionBudget.
+func (in *HawtioPodDisruptionBudget) DeepCopy() *HawtioPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioPodTemplate) DeepCopyInto(out *HawtioPodTemplate) {
+	*out = *in
+	in.Online.DeepCopyInto(&out.Online)
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioPodTemplate.
+func (in *HawtioPodTemplate) DeepCopy() *HawtioPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioProbe) DeepCopyInto(out *HawtioProbe) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessThreshold != nil {
+		in, out := &in.SuccessThreshold, &out.SuccessThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioProbe.
+func (in *HawtioProbe) DeepCopy() *HawtioProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioProbes) DeepCopyInto(out *HawtioProbes) {
+	*out = *in
+	in.Online.DeepCopyInto(&out.Online)
+	in.Gateway.DeepCopyInto(&out.Gateway)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioProbes.
+func (in *HawtioProbes) DeepCopy() *HawtioProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioProductInfo) DeepCopyInto(out *HawtioProductInfo) {
 	*out = *in
@@ -305,11 +697,60 @@ func (in *HawtioRBAC) DeepCopy() *HawtioRBAC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioRollout) DeepCopyInto(out *HawtioRollout) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioRollout.
+func (in *HawtioRollout) DeepCopy() *HawtioRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioRoute) DeepCopyInto(out *HawtioRoute) {
 	*out = *in
 	out.CertSecret = in.CertSecret
 	in.CaCert.DeepCopyInto(&out.CaCert)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioRoute.
@@ -322,6 +763,73 @@ func (in *HawtioRoute) DeepCopy() *HawtioRoute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioSecurityContext) DeepCopyInto(out *HawtioSecurityContext) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioSecurityContext.
+func (in *HawtioSecurityContext) DeepCopy() *HawtioSecurityContext {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioSecurityContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HawtioService) DeepCopyInto(out *HawtioService) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.SessionAffinityTimeoutSeconds != nil {
+		in, out := &in.SessionAffinityTimeoutSeconds, &out.SessionAffinityTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioService.
+func (in *HawtioService) DeepCopy() *HawtioService {
+	if in == nil {
+		return nil
+	}
+	out := new(HawtioService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioSpec) DeepCopyInto(out *HawtioSpec) {
 	*out = *in
@@ -331,7 +839,18 @@ func (in *HawtioSpec) DeepCopyInto(out *HawtioSpec) {
 		**out = **in
 	}
 	in.MetadataPropagation.DeepCopyInto(&out.MetadataPropagation)
+	in.Service.DeepCopyInto(&out.Service)
+	in.Access.DeepCopyInto(&out.Access)
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
 	in.Route.DeepCopyInto(&out.Route)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.HTTPRoute.DeepCopyInto(&out.HTTPRoute)
+	out.Exposure = in.Exposure
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExternalRoutes != nil {
 		in, out := &in.ExternalRoutes, &out.ExternalRoutes
 		*out = make([]string, len(*in))
@@ -341,9 +860,48 @@ func (in *HawtioSpec) DeepCopyInto(out *HawtioSpec) {
 	out.Nginx = in.Nginx
 	in.RBAC.DeepCopyInto(&out.RBAC)
 	in.Resources.DeepCopyInto(&out.Resources)
+	in.ContainerResources.DeepCopyInto(&out.ContainerResources)
 	in.Config.DeepCopyInto(&out.Config)
 	out.Logging = in.Logging
 	in.HealthChecks.DeepCopyInto(&out.HealthChecks)
+	in.Probes.DeepCopyInto(&out.Probes)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.SecurityContext.DeepCopyInto(&out.SecurityContext)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	in.Images.DeepCopyInto(&out.Images)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioSpec.
@@ -359,6 +917,18 @@ func (in *HawtioSpec) DeepCopy() *HawtioSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HawtioStatus) DeepCopyInto(out *HawtioStatus) {
 	*out = *in
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HawtioStatus.
@@ -23,18 +23,24 @@ type ApiServerSpec struct {
 	ImageStreams      bool   // Set to true if the API Server supports imagestreams
 	Routes            bool   // Set to true if the API Server supports routes
 	ConsoleLink       bool   // Set to true if the API Server support the openshift console link API
+	GatewayAPI        bool   // Set to true if the API Server supports the gateway API HTTPRoutes
+	BackendTLSPolicy  bool   // Set to true if the API Server supports the gateway API BackendTLSPolicies
 }
 
 type RequiredApiSpec struct {
-	routes       string
-	imagestreams string
-	consolelinks string
+	routes             string
+	imagestreams       string
+	consolelinks       string
+	httproutes         string
+	backendtlspolicies string
 }
 
 var RequiredApi = RequiredApiSpec{
-	routes:       "routes.route.openshift.io/v1",
-	imagestreams: "imagestreams.image.openshift.io/v1",
-	consolelinks: "consolelinks.console.openshift.io/v1",
+	routes:             "routes.route.openshift.io/v1",
+	imagestreams:       "imagestreams.image.openshift.io/v1",
+	consolelinks:       "consolelinks.console.openshift.io/v1",
+	httproutes:         "httproutes.gateway.networking.k8s.io/v1",
+	backendtlspolicies: "backendtlspolicies.gateway.networking.k8s.io/v1",
 }
 
 func contains(a []string, x string) bool {
@@ -90,6 +96,8 @@ func APICapabilities(ctx context.Context, apiClient kclient.Interface, configCli
 	apiSpec.Routes = contains(resIndex, RequiredApi.routes)
 	apiSpec.ImageStreams = contains(resIndex, RequiredApi.imagestreams)
 	apiSpec.ConsoleLink = contains(resIndex, RequiredApi.consolelinks)
+	apiSpec.GatewayAPI = contains(resIndex, RequiredApi.httproutes)
+	apiSpec.BackendTLSPolicy = contains(resIndex, RequiredApi.backendtlspolicies)
 
 	apiSpec.IsOpenShift4 = false
 
@@ -265,6 +265,15 @@ func Test_ApiCapabilitiesOpenshift3(t *testing.T) {
 
 func Test_ApiCapabilitiesKubernetes(t *testing.T) {
 
+	gatewayRes := metav1.APIResourceList{
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "gateways"},
+			{Name: "httproutes"},
+			{Name: "backendtlspolicies"},
+		},
+	}
+
 	testCases := []struct {
 		name     string
 		resList  []*metav1.APIResourceList
@@ -282,6 +291,20 @@ func Test_ApiCapabilitiesKubernetes(t *testing.T) {
 				Routes:            false,
 			},
 		},
+		{
+			"Kubernetes with the gateway API",
+			[]*metav1.APIResourceList{&gatewayRes},
+			ApiServerSpec{
+				Version:           "1.26",
+				KubeVersion:       "1.26",
+				IsOpenShift4:      false,
+				IsOpenShift43Plus: false,
+				ImageStreams:      false,
+				Routes:            false,
+				GatewayAPI:        true,
+				BackendTLSPolicy:  true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -320,6 +343,14 @@ func Test_ApiCapabilitiesKubernetes(t *testing.T) {
 				t.Error("Expected api specification image streams not expected")
 			}
 
+			if apiSpec.GatewayAPI != tc.expected.GatewayAPI {
+				t.Error("Expected api specification gateway API not expected")
+			}
+
+			if apiSpec.BackendTLSPolicy != tc.expected.BackendTLSPolicy {
+				t.Error("Expected api specification backend TLS policy not expected")
+			}
+
 		})
 	}
 }
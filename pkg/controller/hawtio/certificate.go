@@ -2,14 +2,18 @@ package hawtio
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
-	rand2 "math/rand"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -21,8 +25,8 @@ import (
 	"github.com/hawtio/hawtio-operator/pkg/resources"
 )
 
-func generateSelfSignedCertSecret(hawtio *hawtiov2.Hawtio, name string, namespace string, commonName string, expirationDate time.Time) (*corev1.Secret, error) {
-	return generateCertificateSecret(hawtio, name, namespace, nil, commonName, expirationDate)
+func generateSelfSignedCertSecret(hawtio *hawtiov2.Hawtio, name string, namespace string, commonName string, dnsNames []string, expirationDate time.Time) (*corev1.Secret, error) {
+	return generateCertificateSecret(hawtio, name, namespace, nil, commonName, dnsNames, expirationDate)
 }
 
 func generateCASignedCertSecret(hawtio *hawtiov2.Hawtio, name string, namespace string, caSecret *corev1.Secret, commonName string, expirationDate time.Time) (*corev1.Secret, error) {
@@ -30,10 +34,10 @@ func generateCASignedCertSecret(hawtio *hawtiov2.Hawtio, name string, namespace
 		return nil, errors.New("Generating a CA-signed certificate requires the CA Secret")
 	}
 
-	return generateCertificateSecret(hawtio, name, namespace, caSecret, commonName, expirationDate)
+	return generateCertificateSecret(hawtio, name, namespace, caSecret, commonName, nil, expirationDate)
 }
 
-func generateCertificateSecret(hawtio *hawtiov2.Hawtio, name string, namespace string, caSecret *corev1.Secret, commonName string, expirationDate time.Time) (*corev1.Secret, error) {
+func generateCertificateSecret(hawtio *hawtiov2.Hawtio, name string, namespace string, caSecret *corev1.Secret, commonName string, dnsNames []string, expirationDate time.Time) (*corev1.Secret, error) {
 	var caCert *x509.Certificate
 	var caPrivateKey crypto.PrivateKey
 	var err error
@@ -54,22 +58,42 @@ func generateCertificateSecret(hawtio *hawtiov2.Hawtio, name string, namespace s
 		if pemBlock == nil {
 			return nil, errors.New("failed to decode CA certificate signing key")
 		}
-		caPrivateKey, err = x509.ParsePKCS1PrivateKey(pemBlock.Bytes)
+		caPrivateKey, err = parsePrivateKey(pemBlock.Bytes)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	serialNumber := big.NewInt(rand2.Int63())
+	// generate cert private key
+	certPrivateKey, privateKeyPem, err := generatePrivateKey(hawtio.Spec.Auth.ClientCertKeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	// A positive random serial number of up to 128 bits, as recommended by RFC 5280
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, ok := certPrivateKey.(*rsa.PrivateKey); ok {
+		// The RSA key exchange encrypts the session key with the public key
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
 	cert := &x509.Certificate{
 		SerialNumber: serialNumber,
 		Subject: pkix.Name{
 			CommonName: commonName,
 		},
-		NotBefore:   time.Now(),
-		NotAfter:    expirationDate,
-		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:    x509.KeyUsageDigitalSignature,
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now(),
+		NotAfter:              expirationDate,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              keyUsage,
+		BasicConstraintsValid: true,
+		IsCA:                  false,
 	}
 
 	if caCert == nil {
@@ -77,26 +101,12 @@ func generateCertificateSecret(hawtio *hawtiov2.Hawtio, name string, namespace s
 		caCert = cert
 	}
 
-	// generate cert private key
-	certPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, err
-	}
-
 	if caPrivateKey == nil {
 		// No CA certificate provided so create self-signed certificate
 		caPrivateKey = certPrivateKey
 	}
 
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(certPrivateKey)
-	// encode for storing into secret
-	privateKeyPem := pem.EncodeToMemory(
-		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
-			Bytes: privateKeyBytes,
-		},
-	)
-	certBytes, err := x509.CreateCertificate(rand.Reader, cert, caCert, &certPrivateKey.PublicKey, caPrivateKey)
+	certBytes, err := x509.CreateCertificate(rand.Reader, cert, caCert, certPrivateKey.Public(), caPrivateKey)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +132,87 @@ func generateCertificateSecret(hawtio *hawtiov2.Hawtio, name string, namespace s
 	}, nil
 }
 
+// generatePrivateKey generates a private key with the given algorithm, that
+// defaults to RSA-2048, and returns it along with its PEM encoding
+func generatePrivateKey(algorithm hawtiov2.HawtioKeyAlgorithm) (crypto.Signer, []byte, error) {
+	var curve elliptic.Curve
+	bits := 2048
+
+	switch algorithm {
+	case hawtiov2.ECDSAP256HawtioKeyAlgorithm:
+		curve = elliptic.P256()
+	case hawtiov2.ECDSAP384HawtioKeyAlgorithm:
+		curve = elliptic.P384()
+	case hawtiov2.RSA3072HawtioKeyAlgorithm:
+		bits = 3072
+	case hawtiov2.RSA4096HawtioKeyAlgorithm:
+		bits = 4096
+	}
+
+	if curve != nil {
+		privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes}), nil
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
+	return privateKey, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: privateKeyBytes}), nil
+}
+
+// parsePrivateKey parses the DER encoded private key, either PKCS #1, PKCS #8 or SEC 1
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	return x509.ParseECPrivateKey(der)
+}
+
+// getKeyAlgorithm returns the algorithm of the given public key, or an empty
+// string if it cannot be generated by the operator
+func getKeyAlgorithm(publicKey crypto.PublicKey) hawtiov2.HawtioKeyAlgorithm {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return hawtiov2.HawtioKeyAlgorithm(fmt.Sprintf("RSA-%d", key.N.BitLen()))
+	case *ecdsa.PublicKey:
+		return hawtiov2.HawtioKeyAlgorithm("ECDSA-" + strings.ReplaceAll(key.Curve.Params().Name, "-", ""))
+	}
+	return ""
+}
+
+// isCertificateOutdated returns whether the certificate of the secret no longer
+// matches the configuration, ie. its DNS names or the algorithm of its key
+func isCertificateOutdated(hawtio *hawtiov2.Hawtio, secret *corev1.Secret, dnsNames []string) bool {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return true
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+
+	algorithm := hawtio.Spec.Auth.ClientCertKeyAlgorithm
+	if algorithm == "" {
+		algorithm = hawtiov2.RSA2048HawtioKeyAlgorithm
+	}
+
+	return !slices.Equal(cert.DNSNames, dnsNames) || getKeyAlgorithm(cert.PublicKey) != algorithm
+}
+
 func certificateExpiryPeriod(hawtio *hawtiov2.Hawtio) time.Duration {
 	periodHours := hawtio.Spec.Auth.ClientCertExpirationPeriod
 	if periodHours == 0 {
@@ -22,30 +22,21 @@ import (
 // Maximum time to sleep before a requeue should take place
 var maxRequeueTime = 24 * time.Hour
 
+// verifyHawtioSpecType checks the (defaulted) spec type is supported,
+// otherwise the Hawtio CR is marked as failed
 func (r *ReconcileHawtio) verifyHawtioSpecType(ctx context.Context, hawtio *hawtiov2.Hawtio) (bool, error) {
-	if len(hawtio.Spec.Type) == 0 {
-		r.logger.V(util.DebugLogLevel).Info("Hawtio.Spec.Type not specified. Defaulting to Cluster")
-
-		previous := hawtio.DeepCopy()
-		hawtio.Spec.Type = hawtiov2.ClusterHawtioDeploymentType
-
-		// Use patch rather than update to ensure only the
-		// explicit changes are merged in rather than potentially
-		// overwriting with a stale hawtio CR
-		err := r.client.Patch(ctx, hawtio, client.MergeFrom(previous))
-		if err != nil {
-			return false, fmt.Errorf("failed to update type: %v", err)
-		}
-
-		return true, nil // CR was updated with no error
-	}
-
 	if hawtio.Spec.Type != hawtiov2.NamespaceHawtioDeploymentType && (hawtio.Spec.Type != hawtiov2.ClusterHawtioDeploymentType) {
 		r.logger.V(util.DebugLogLevel).Info("Hawtio.Spec.Type neither Cluster or Namespace")
 
-		err := r.setHawtioPhase(ctx, hawtio, hawtiov2.HawtioPhaseFailed)
+		message := fmt.Sprintf("Unsupported type %q, expected either %s or %s", hawtio.Spec.Type, hawtiov2.ClusterHawtioDeploymentType, hawtiov2.NamespaceHawtioDeploymentType)
+		err := r.updateStatus(ctx, hawtio, func(status *hawtiov2.HawtioStatus) {
+			status.Phase = hawtiov2.HawtioPhaseFailed
+			status.ObservedGeneration = hawtio.Generation
+			setCondition(hawtio, status, hawtiov2.HawtioConditionDegraded, metav1.ConditionTrue, reasonUnsupportedType, message)
+			setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reasonUnsupportedType, message)
+		})
 		if err != nil {
-			return false, err
+			return false, fmt.Errorf("failed to update hawtio phase to %s: %v", hawtiov2.HawtioPhaseFailed, err)
 		}
 
 		return true, fmt.Errorf("unsupported type: %s", hawtio.Spec.Type) // CR was updated and report unsupported type error
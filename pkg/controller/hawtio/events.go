@@ -0,0 +1,16 @@
+package hawtio
+
+// The reasons of the events recorded against the Hawtio CR
+const (
+	EventReasonLegacyResourceAdopted = "LegacyResourceAdopted"
+	EventReasonAdoptionFailed        = "AdoptionFailed"
+	EventReasonCertificateCreated    = "CertificateCreated"
+	EventReasonCertificateRotated    = "CertificateRotated"
+	EventReasonCertificateFailed     = "CertificateFailed"
+	EventReasonRouteRegenerating     = "RouteRegenerating"
+	EventReasonRouteDeletionFailed   = "RouteDeletionFailed"
+	EventReasonImagesUpdated         = "ImagesUpdated"
+	EventReasonUnsupportedVersion    = "UnsupportedVersion"
+	EventReasonSourceRangesIgnored   = "SourceRangesIgnored"
+	EventReasonPodTemplateIgnored    = "PodTemplateIgnored"
+)
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -22,8 +23,11 @@ import (
 	fakeconfig "github.com/openshift/client-go/config/clientset/versioned/fake"
 	fakeoauth "github.com/openshift/client-go/oauth/clientset/versioned/fake"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	discoveryfake "k8s.io/client-go/discovery/fake"
 	fakekube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/hawtio/hawtio-operator/pkg/apis"
 	"github.com/hawtio/hawtio-operator/pkg/capabilities"
@@ -43,6 +47,11 @@ func buildReconcileWithFakeClientWithMocks(objs []client.Object, t *testing.T) *
 		assert.Fail(t, "unable to build scheme")
 	}
 
+	err = autoscalingv2.AddToScheme(scheme)
+	if err != nil {
+		assert.Fail(t, "unable to build scheme")
+	}
+
 	err = rbacv1.AddToScheme(scheme)
 	if err != nil {
 		assert.Fail(t, "unable to build scheme")
@@ -58,6 +67,11 @@ func buildReconcileWithFakeClientWithMocks(objs []client.Object, t *testing.T) *
 		assert.Fail(t, "unable to build scheme")
 	}
 
+	err = policyv1.AddToScheme(scheme)
+	if err != nil {
+		assert.Fail(t, "unable to build scheme")
+	}
+
 	err = apis.AddToScheme(scheme)
 	if err != nil {
 		assert.Fail(t, "unable to build scheme")
@@ -73,6 +87,11 @@ func buildReconcileWithFakeClientWithMocks(objs []client.Object, t *testing.T) *
 		assert.Fail(t, "unable to build scheme")
 	}
 
+	err = gatewayv1.Install(scheme)
+	if err != nil {
+		assert.Fail(t, "unable to build scheme")
+	}
+
 	client := fake.NewClientBuilder().WithScheme(scheme).
 		WithStatusSubresource(objs...).
 		WithObjects(objs...).
@@ -96,10 +115,12 @@ func buildReconcileWithFakeClientWithMocks(objs []client.Object, t *testing.T) *
 	return &ReconcileHawtio{
 		scheme:       scheme,
 		client:       client,
+		apiReader:    client,
 		configClient: configClient,
 		coreClient:   coreClient,
 		oauthClient:  fakeoauth.NewSimpleClientset(),
 		apiClient:    apiClient,
 		apiSpec:      apiSpec,
+		recorder:     record.NewFakeRecorder(100),
 	}
 }
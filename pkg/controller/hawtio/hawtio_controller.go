@@ -4,21 +4,24 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"reflect"
 	"time"
 
 	errs "github.com/pkg/errors"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kclient "k8s.io/client-go/kubernetes"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -30,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	routev1 "github.com/openshift/api/route/v1"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
@@ -39,6 +43,7 @@ import (
 	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/openshift"
 	kresources "github.com/hawtio/hawtio-operator/pkg/resources/kubernetes"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
 	oresources "github.com/hawtio/hawtio-operator/pkg/resources/openshift"
 	"github.com/hawtio/hawtio-operator/pkg/util"
 	"github.com/hawtio/hawtio-operator/pkg/clients"
@@ -67,6 +72,7 @@ type ReconcileHawtio struct {
 	configClient  configclient.Interface
 	apiClient     kclient.Interface
 	apiSpec       *capabilities.ApiServerSpec
+	recorder      record.EventRecorder
 	logger        logr.Logger
 	operatorPod   types.NamespacedName
 	updatePoller  *updater.RegistryPoller
@@ -90,6 +96,7 @@ func Add(mgr manager.Manager, operatorPod types.NamespacedName, clientTools *cli
 		configClient:   clientTools.ConfigClient,
 		apiClient:      clientTools.ApiClient,
 		apiSpec:        apiSpec,
+		recorder:       mgr.GetEventRecorderFor("hawtio-controller"),
 		operatorPod:    operatorPod,
 		updatePoller:   updatePoller,
 		updateChannel:  updateChannel,
@@ -150,10 +157,29 @@ func Add(mgr manager.Manager, operatorPod types.NamespacedName, clientTools *cli
 		if err != nil {
 			return errs.Wrap(err, "Failed to create watch for Route resource")
 		}
-	} else {
-		err = c.Watch(source.Kind(mgr.GetCache(), &networkingv1.Ingress{}, enqueueRequestForOwner[*networkingv1.Ingress](mgr)))
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), &networkingv1.Ingress{}, enqueueRequestForOwner[*networkingv1.Ingress](mgr)))
+	if err != nil {
+		return errs.Wrap(err, "Failed to create watch for Ingress resource")
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), &networkingv1.NetworkPolicy{}, enqueueRequestForOwner[*networkingv1.NetworkPolicy](mgr)))
+	if err != nil {
+		return errs.Wrap(err, "Failed to create watch for NetworkPolicy resource")
+	}
+
+	if r.apiSpec.GatewayAPI {
+		err = c.Watch(source.Kind(mgr.GetCache(), &gatewayv1.HTTPRoute{}, enqueueRequestForOwner[*gatewayv1.HTTPRoute](mgr)))
 		if err != nil {
-			return errs.Wrap(err, "Failed to create watch for Ingress resource")
+			return errs.Wrap(err, "Failed to create watch for HTTPRoute resource")
+		}
+	}
+
+	if r.apiSpec.BackendTLSPolicy {
+		err = c.Watch(source.Kind(mgr.GetCache(), &gatewayv1.BackendTLSPolicy{}, enqueueRequestForOwner[*gatewayv1.BackendTLSPolicy](mgr)))
+		if err != nil {
+			return errs.Wrap(err, "Failed to create watch for BackendTLSPolicy resource")
 		}
 	}
 
@@ -180,6 +206,16 @@ func Add(mgr manager.Manager, operatorPod types.NamespacedName, clientTools *cli
 		return errs.Wrap(err, "Failed to create watch for Deployment resource")
 	}
 
+	err = c.Watch(source.Kind(mgr.GetCache(), &policyv1.PodDisruptionBudget{}, enqueueRequestForOwner[*policyv1.PodDisruptionBudget](mgr)))
+	if err != nil {
+		return errs.Wrap(err, "Failed to create watch for PodDisruptionBudget resource")
+	}
+
+	err = c.Watch(source.Kind(mgr.GetCache(), &autoscalingv2.HorizontalPodAutoscaler{}, enqueueRequestForOwner[*autoscalingv2.HorizontalPodAutoscaler](mgr)))
+	if err != nil {
+		return errs.Wrap(err, "Failed to create watch for HorizontalPodAutoscaler resource")
+	}
+
 	//watch secret
 	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, enqueueRequestForOwner[*corev1.Secret](mgr)))
 	if err != nil {
@@ -231,6 +267,7 @@ var _ reconcile.Reconciler = &ReconcileHawtio{}
 type DeploymentConfiguration struct {
 	openShiftConsoleURL string
 	configMap           *corev1.ConfigMap
+	release             *resources.OperandRelease
 	clientCertSecret    *corev1.Secret // -proxying certificate secret
 	tlsRouteSecret      *corev1.Secret // custom route certificate secret
 	caCertRouteSecret   *corev1.Secret // custom CA certificate secret
@@ -266,6 +303,11 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, nil
 	}
 
+	// Fill in any defaults not persisted by the defaulting webhook, eg. if it is
+	// not installed. These are only applied in memory so the user spec is never
+	// mutated by the reconciler.
+	resources.ApplyDefaults(hawtio)
+
 	// =====================================================================
 	// PHASE 2: DELETION AND FINALIZERS
 	// =====================================================================
@@ -304,20 +346,60 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 	valid, err := r.verifyRBACConfigMap(ctx, hawtio, crNamespacedName)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
+			r.reportInvalidRBACConfigMap(ctx, hawtio, reasonConfigMapNotFound,
+				fmt.Sprintf("RBAC ConfigMap %s not found", hawtio.Spec.RBAC.ConfigMap))
 			// Let's poll for the RBAC ConfigMap to be created
 			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 		} else {
-			return reconcile.Result{}, err
+			return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionRBACConfigValid, reasonReconcileFailed, err)
 		}
 	} else if !valid {
+		r.reportInvalidRBACConfigMap(ctx, hawtio, reasonConfigMapInvalid,
+			fmt.Sprintf("RBAC ConfigMap %s does not contain the key %s", hawtio.Spec.RBAC.ConfigMap, resources.RBACConfigMapKey))
 		// Lets poll until the RBAC ConfigMap is valid
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 	}
 
+	if hawtio.Spec.RBAC.ConfigMap == "" {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionRBACConfigValid, metav1.ConditionTrue, reasonDefaultRBAC, "No RBAC ConfigMap specified, using the default ACL")
+	} else {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionRBACConfigValid, metav1.ConditionTrue, reasonConfigMapValid,
+			fmt.Sprintf("RBAC ConfigMap %s is valid", hawtio.Spec.RBAC.ConfigMap))
+	}
+
+	// Check the version of the console release.
+	// If specified in the CR then it should be supported, otherwise the
+	// console keeps being reconciled with the release the operator is built
+	// for, so that CRs with versions dropped by an upgrade are not orphaned.
+	// The webhooks reject unsupported versions, so this only happens once the
+	// operator no longer ships the release. Leaving the Deployment as is would
+	// keep running images whose environment and mounts the operator cannot
+	// derive anymore, and none of the other fixes would be rolled out.
+	r.logger.V(util.DebugLogLevel).Info("=== Verifying Version ===")
+	release, err := resources.SelectOperandRelease(hawtio)
+	if err != nil {
+		message := fmt.Sprintf("%s, using the release the operator is built for", err.Error())
+		if turnsFalse(&hawtio.Status, hawtiov2.HawtioConditionVersionSupported) {
+			r.recorder.Event(hawtio, corev1.EventTypeWarning, EventReasonUnsupportedVersion, message)
+		}
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionVersionSupported, metav1.ConditionFalse, reasonUnsupportedVersion, message)
+	} else if release == nil {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionVersionSupported, metav1.ConditionTrue, reasonDefaultVersion, "No version specified, using the release the operator is built for")
+	} else {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionVersionSupported, metav1.ConditionTrue, reasonVersionSupported,
+			fmt.Sprintf("Version %s is supported", release.Version))
+	}
+
 	if len(hawtio.Status.Phase) == 0 || hawtio.Status.Phase == hawtiov2.HawtioPhaseFailed {
 		r.logger.V(util.DebugLogLevel).Info("Hawtio.Status.Phase is zero or failed. Setting to initialized.")
-		err := r.setHawtioPhase(ctx, hawtio, hawtiov2.HawtioPhaseInitialized)
+		err := r.updateStatus(ctx, hawtio, func(status *hawtiov2.HawtioStatus) {
+			status.Phase = hawtiov2.HawtioPhaseInitialized
+			status.ObservedGeneration = hawtio.Generation
+			setCondition(hawtio, status, hawtiov2.HawtioConditionProgressing, metav1.ConditionTrue, reasonInitializing, "The console is being initialized")
+			setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reasonInitializing, "The console is being initialized")
+		})
 		if err != nil {
+			err = fmt.Errorf("failed to update hawtio phase to %s: %v", hawtiov2.HawtioPhaseInitialized, err)
 			return reconcile.Result{}, err
 		}
 
@@ -328,40 +410,59 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 	// PHASE 4: RECONCILE AND DEPLOY PHASE
 	// =====================================================================
 
-	// Reconcile the service account
-	// - may include OAuth Client annotations, if applicable
+	// Reconcile the service account, before the deployment that runs as it
+	// - its OAuth Client annotations, if applicable, are reconciled once the
+	//   console has been exposed
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling Service Account ===")
 	opResult, err := r.reconcileServiceAccount(ctx, hawtio)
 	r.logOperationResult("ServiceAccount", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, "", "ServiceAccountFailed", err)
 	}
 
 	// Intialize the deployment inputs required for the deployment resources
 	r.logger.V(util.DebugLogLevel).Info("=== Initializing Deployment Configuration ===")
 	deploymentConfig, err := r.initDeploymentConfiguration(ctx, hawtio)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionCertificatesValid, reasonCertificateError, err)
 	}
+	setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionCertificatesValid, metav1.ConditionTrue, reasonCertificatesValid, "The console certificates are present and valid")
 
 	// Reconcile the configMap to ensure it is present for use with the deployment
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling ConfigMap ===")
 	configMap, opResult, err := r.reconcileConfigMap(ctx, hawtio)
 	r.logOperationResult("ConfigMap", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, "", "ConfigMapFailed", err)
 	}
 
 	// Makes the configMap available to the deployment
 	r.logger.V(util.DebugLogLevel).Info(fmt.Sprintf("Assigning reconciled config map %s to deployment", crNamespacedName.Name))
 	deploymentConfig.configMap = configMap
+	deploymentConfig.release = release
 
 	// Reconcile the deployment resource
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling Deployment ===")
 	opResult, err = r.reconcileDeployment(ctx, hawtio, deploymentConfig)
 	r.logOperationResult("Deployment", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, "", "DeploymentFailed", err)
+	}
+
+	// Reconcile the pod disruption budget resource
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling PodDisruptionBudget ===")
+	opResult, err = r.reconcilePodDisruptionBudget(ctx, hawtio)
+	r.logOperationResult("PodDisruptionBudget", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, "", "PodDisruptionBudgetFailed", err)
+	}
+
+	// Reconcile the horizontal pod autoscaler resource
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling HorizontalPodAutoscaler ===")
+	opResult, err = r.reconcileHorizontalPodAutoscaler(ctx, hawtio)
+	r.logOperationResult("HorizontalPodAutoscaler", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, "", "HorizontalPodAutoscalerFailed", err)
 	}
 
 	// Reconcile the service resource
@@ -369,19 +470,28 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 	opResult, err = r.reconcileService(ctx, hawtio)
 	r.logOperationResult("Service", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, "", "ServiceFailed", err)
 	}
 
-	// Declare this for use later in the OAuthClient and Hawtio.Status
+	// Reconcile the network policy resource
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling NetworkPolicy ===")
+	opResult, err = r.reconcileNetworkPolicy(ctx, hawtio)
+	r.logOperationResult("NetworkPolicy", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, "", "NetworkPolicyFailed", err)
+	}
+
+	// Declare these for use later in the OAuthClient and Hawtio.Status
 	var ingressRouteURL string
+	var ingressRouteURLs []string
 
 	// Reconcile the route resource, if applicable
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling Route ===")
 	route, opResult, err := r.reconcileRoute(ctx, hawtio, deploymentConfig)
 	r.logOperationResult("Route", opResult)
 	if err != nil {
-		return handleResultAndError(err)
-	} else if route == nil && opResult != controllerutil.OperationResultNone {
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionRouteAdmitted, "RouteFailed", err)
+	} else if route == nil && opResult != controllerutil.OperationResultNone && oresources.IsRouteApplicable(hawtio, r.apiSpec) {
 		// This means the route was intentionally deleted to be regenerated.
 		// Stop this loop and wait for the automatic requeue that the delete
 		// event will trigger.
@@ -389,6 +499,65 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, nil
 	} else if route != nil {
 		ingressRouteURL = oresources.GetRouteURL(route)
+		ingressRouteURLs = []string{ingressRouteURL}
+		if admitted, reason, message := oresources.GetRouteAdmission(route); admitted {
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionRouteAdmitted, metav1.ConditionTrue, reasonAdmitted, "The route has been admitted")
+		} else {
+			if reason == "" {
+				reason, message = reasonAdmissionPending, "Waiting for the route to be admitted"
+			}
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionRouteAdmitted, metav1.ConditionFalse, reason, message)
+		}
+	} else if hawtio.Spec.Exposure.Type == hawtiov2.RouteHawtioExposureType {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionRouteAdmitted, metav1.ConditionFalse, reasonRoutesUnavailable,
+			"The cluster does not support routes, the route cannot be created")
+	} else {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionRouteAdmitted)
+	}
+
+	// Reconcile the routes of the additional host names, if applicable
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling Alias Routes ===")
+	aliasRoutes, opResult, err := r.reconcileAliasRoutes(ctx, hawtio, deploymentConfig)
+	r.logOperationResult("AliasRoutes", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionRouteAdmitted, "RouteFailed", err)
+	}
+	for _, aliasRoute := range aliasRoutes {
+		ingressRouteURLs = append(ingressRouteURLs, oresources.GetRouteURL(aliasRoute))
+	}
+
+	// Reconcile the Gateway API HTTPRoute resource, if applicable
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling HTTPRoute ===")
+	httpRoute, opResult, err := r.reconcileHTTPRoute(ctx, hawtio)
+	r.logOperationResult("HTTPRoute", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionHTTPRouteAccepted, "HTTPRouteFailed", err)
+	} else if httpRoute != nil {
+		ingressRouteURL = kresources.GetHTTPRouteURL(httpRoute, r.getParentGateway(ctx, hawtio))
+		ingressRouteURLs = []string{ingressRouteURL}
+		if accepted, reason, message := kresources.GetHTTPRouteAcceptance(httpRoute); accepted {
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionHTTPRouteAccepted, metav1.ConditionTrue, reasonAccepted, "The HTTPRoute has been accepted by the Gateway")
+		} else {
+			if reason == "" {
+				reason, message = reasonAcceptancePending, "Waiting for the HTTPRoute to be accepted by the Gateway"
+			}
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionHTTPRouteAccepted, metav1.ConditionFalse, reason, message)
+		}
+	} else if !r.apiSpec.GatewayAPI && (hawtio.Spec.Exposure.Type == hawtiov2.GatewayAPIHawtioExposureType ||
+		hawtio.Spec.Exposure.Type == "" && hawtio.Spec.HTTPRoute.ParentRef.Name != "") {
+		// The Gateway API is either selected, or referenced while the default exposure applies
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionHTTPRouteAccepted, metav1.ConditionFalse, reasonGatewayAPIUnavailable,
+			"The cluster does not support the Gateway API, the HTTPRoute cannot be created")
+	} else {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionHTTPRouteAccepted)
+	}
+
+	// Reconcile the BackendTLSPolicy resource, if applicable
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling BackendTLSPolicy ===")
+	opResult, err = r.reconcileBackendTLSPolicy(ctx, hawtio, deploymentConfig)
+	r.logOperationResult("BackendTLSPolicy", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionHTTPRouteAccepted, "BackendTLSPolicyFailed", err)
 	}
 
 	// Reconcile the ingress resource, if applicable
@@ -396,25 +565,53 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 	ingress, opResult, err := r.reconcileIngress(ctx, hawtio, deploymentConfig)
 	r.logOperationResult("Ingress", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionIngressReady, "IngressFailed", err)
 	} else if ingress != nil {
 		ingressRouteURL = kresources.GetIngressURL(ingress)
+		ingressRouteURLs = kresources.GetIngressURLs(ingress)
+		if len(ingress.Status.LoadBalancer.Ingress) > 0 {
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionIngressReady, metav1.ConditionTrue, reasonAddressAssigned, "The ingress has been assigned an address")
+		} else {
+			setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionIngressReady, metav1.ConditionFalse, reasonAwaitingAddress, "Waiting for the ingress to be assigned an address")
+		}
+	} else {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionIngressReady)
+	}
+
+	// Report whether the allowed source ranges are enforced by the resources above
+	r.reportAccessRestriction(hawtio)
+
+	// Reconcile the OAuth Client annotations of the service account, if applicable,
+	// that redirect to the URLs the console has been exposed at in this pass
+	r.logger.V(util.DebugLogLevel).Info("=== Reconciling Service Account OAuth Redirects ===")
+	opResult, err = r.reconcileOAuthRedirects(ctx, hawtio, ingressRouteURLs)
+	r.logOperationResult("ServiceAccount", opResult)
+	if err != nil {
+		return r.reconcileFailed(ctx, hawtio, "", "ServiceAccountFailed", err)
 	}
 
 	// Reconcile the OAuthClient resource, if applicable
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling OAuth Client ===")
-	opResult, err = r.reconcileOAuthClient(ctx, hawtio, ingressRouteURL, crNamespacedName)
+	opResult, err = r.reconcileOAuthClient(ctx, hawtio, ingressRouteURLs, crNamespacedName)
 	r.logOperationResult("OAuthClient", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionOAuthClientReady, "OAuthClientFailed", err)
+	} else if r.isOAuthClientApplicable(hawtio) {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionOAuthClientReady, metav1.ConditionTrue, reasonReconciled, "The OAuth client has been configured")
+	} else {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionOAuthClientReady)
 	}
 
 	// Reconcile the ConsoleLink resource, if applicable
 	r.logger.V(util.DebugLogLevel).Info("=== Reconciling ConsoleLink ===")
-	opResult, err = r.reconcileConsoleLink(ctx, hawtio, crNamespacedName, deploymentConfig, route)
+	opResult, err = r.reconcileConsoleLink(ctx, hawtio, crNamespacedName, deploymentConfig, ingressRouteURL)
 	r.logOperationResult("ConsoleLink", opResult)
 	if err != nil {
-		return handleResultAndError(err)
+		return r.reconcileFailed(ctx, hawtio, hawtiov2.HawtioConditionConsoleLinkReady, "ConsoleLinkFailed", err)
+	} else if r.isConsoleLinkApplicable(hawtio, ingressRouteURL) {
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionConsoleLinkReady, metav1.ConditionTrue, reasonReconciled, "The console link has been configured")
+	} else {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionConsoleLinkReady)
 	}
 
 	// =====================================================================
@@ -431,6 +628,9 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, err
 	}
 
+	// Retain the conditions gathered by the reconcile steps above
+	conditions := hawtio.Status.Conditions
+
 	// Refresh the hawtio CR to minimize conflict window
 	// Gets the absolute latest ResourceVersion from the server.
 	if err := r.client.Get(ctx, crNamespacedName, hawtio); err != nil {
@@ -439,6 +639,8 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 
 	// Create a copy of the status to modify.
 	newStatus := hawtio.Status.DeepCopy()
+	newStatus.Conditions = conditions
+	newStatus.ObservedGeneration = hawtio.Generation
 
 	// Reconcile status fields from the Deployment.
 	newStatus.Replicas = deployment.Status.Replicas
@@ -452,13 +654,22 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		return reconcile.Result{}, fmt.Errorf("failed to parse selector: %v", err)
 	}
 
-	r.logger.V(util.DebugLogLevel).Info("Adding Route/Ingress URL to Hawtio.Status.URL")
+	r.logger.V(util.DebugLogLevel).Info("Adding Route/HTTPRoute/Ingress URL to Hawtio.Status.URL")
 
 	if r.apiSpec.Routes && route != nil {
 		// Reconcile route URL into Hawtio status
 		newStatus.URL = ingressRouteURL
+		newStatus.URLs = ingressRouteURLs
+	} else if httpRoute != nil {
+		newStatus.URL = ingressRouteURL
+		newStatus.URLs = ingressRouteURLs
 	} else if ingress != nil {
 		newStatus.URL = ingressRouteURL
+		newStatus.URLs = ingressRouteURLs
+	} else {
+		// The console is not exposed outside the cluster
+		newStatus.URL = ""
+		newStatus.URLs = nil
 	}
 
 	// Determine the overall phase based on the deployment's readiness.
@@ -466,7 +677,7 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		newStatus.Phase = hawtiov2.HawtioPhaseDeployed
 	} else {
 		// The Deployment isn't ready yet. Let's check if it has timed out.
-		if r.isDeploymentFailed(deployment) {
+		if r.isDeploymentFailed(hawtio, deployment) {
 			newStatus.Phase = hawtiov2.HawtioPhaseFailed
 		} else {
 			// It's not ready, but it hasn't failed yet. It's likely pulling images
@@ -475,9 +686,11 @@ func (r *ReconcileHawtio) Reconcile(ctx context.Context, request reconcile.Reque
 		}
 	}
 
+	r.setDeploymentConditions(hawtio, newStatus, deployment)
+
 	// Only send an update to the API server if the status has actually changed.
 	// This prevents empty updates and reduces load on the API server.
-	if !reflect.DeepEqual(hawtio.Status, *newStatus) {
+	if !equality.Semantic.DeepEqual(hawtio.Status, *newStatus) {
 		hawtio.Status = *newStatus
 		r.logger.Info("Status has changed, updating Hawtio CR",
 			"Phase", newStatus.Phase,
@@ -2,6 +2,7 @@ package hawtio
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
@@ -16,7 +17,8 @@ import (
 
 var conKLog = logf.Log.WithName("controller_hawtio_kubernetes")
 
-func newSelfCertificateSecret(ctx context.Context, r *ReconcileHawtio, hawtio *hawtiov2.Hawtio, name string, namespace string) (*corev1.Secret, error) {
+// servingCertificateCommonName returns the CN of the self-signed serving certificate
+func servingCertificateCommonName(r *ReconcileHawtio, hawtio *hawtiov2.Hawtio) string {
 	commonName := hawtio.Spec.Auth.ClientCertCommonName
 	if commonName == "" {
 		if r.ClientCertCommonName == "" {
@@ -25,12 +27,33 @@ func newSelfCertificateSecret(ctx context.Context, r *ReconcileHawtio, hawtio *h
 			commonName = r.ClientCertCommonName
 		}
 	}
+	return commonName
+}
+
+// servingCertificateDNSNames returns the SANs of the self-signed serving certificate,
+// ie. the DNS names of the Hawtio service and the host names the console is exposed at
+func servingCertificateDNSNames(hawtio *hawtiov2.Hawtio) []string {
+	service := hawtio.Name + "." + hawtio.Namespace
+	dnsNames := []string{hawtio.Name, service, service + ".svc", service + ".svc.cluster.local"}
+
+	hosts := slices.Concat([]string{hawtio.Spec.RouteHostName}, hawtio.Spec.Ingress.Hosts, hawtio.Spec.HTTPRoute.Hostnames, hawtio.Spec.Hosts)
+	for _, host := range hosts {
+		if host != "" && !slices.Contains(dnsNames, host) {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+
+	return dnsNames
+}
+
+func newSelfCertificateSecret(ctx context.Context, r *ReconcileHawtio, hawtio *hawtiov2.Hawtio, name string, namespace string) (*corev1.Secret, error) {
+	commonName := servingCertificateCommonName(r, hawtio)
 	// Let's default to one year validity period
 	expirationDate := time.Now().AddDate(1, 0, 0)
 	if date := hawtio.Spec.Auth.ClientCertExpirationDate; date != nil && !date.IsZero() {
 		expirationDate = date.Time
 	}
-	servingCertSecret, err := generateSelfSignedCertSecret(hawtio, name, namespace, commonName, expirationDate)
+	servingCertSecret, err := generateSelfSignedCertSecret(hawtio, name, namespace, commonName, servingCertificateDNSNames(hawtio), expirationDate)
 	if err != nil {
 		return nil, errs.Wrap(err, "Generating the serving certificate failed")
 	}
@@ -53,7 +76,7 @@ func kubeCreateServingCertificate(ctx context.Context, r *ReconcileHawtio, hawti
 			// This a legacy certificate so adopt it
 			// Note: adoptLegacyResource returns the Sentinel Error (ErrLegacyResourceAdopted)
 			// on success.
-			adoptErr := r.adoptLegacyResource(ctx, servingCertSecret)
+			adoptErr := r.adoptLegacyResource(ctx, hawtio, servingCertSecret)
 			if adoptErr != nil {
 				// Returns ErrLegacyResourceAdopted (to requeue) or a real API error
 				return nil, 0, adoptErr
@@ -62,15 +85,18 @@ func kubeCreateServingCertificate(ctx context.Context, r *ReconcileHawtio, hawti
 
 		//
 		// Check the secret's certificate validity.
-		// Is the secret certificate invalid (expired).
+		// Is the secret certificate invalid (expired) or outdated (its
+		// DNS names or key algorithm changed).
 		// If so they need to update it with a new certificate.
 		//
 		expiryIn := checkCertificateExpiry(hawtio, servingCertSecret, r.logger)
-		if expiryIn == 0 {
-			// certificate is invalid or close to expiring
+		if expiryIn == 0 || isCertificateOutdated(hawtio, servingCertSecret, servingCertificateDNSNames(hawtio)) {
+			// certificate is invalid, outdated or close to expiring
 			// create a new one and update the secret
 			newSecret, err := newSelfCertificateSecret(ctx, r, hawtio, servingCertSecret.Name, servingCertSecret.Namespace)
 			if err != nil {
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+					"Failed to rotate the serving certificate in secret %s: %v", servingCertSecret.Name, err)
 				return nil, 0, err
 			}
 
@@ -85,9 +111,14 @@ func kubeCreateServingCertificate(ctx context.Context, r *ReconcileHawtio, hawti
 
 			// Commit the update
 			if err := r.client.Update(ctx, servingCertSecret); err != nil {
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+					"Failed to rotate the serving certificate in secret %s: %v", servingCertSecret.Name, err)
 				return nil, 0, err
 			}
 
+			r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonCertificateRotated,
+				"Rotated the serving certificate in secret %s", servingCertSecret.Name)
+
 			// reset expiryIn to maximum as new certificate
 			expiryIn = certificateExpiryPeriod(hawtio)
 		}
@@ -100,6 +131,8 @@ func kubeCreateServingCertificate(ctx context.Context, r *ReconcileHawtio, hawti
 
 		servingCertSecret, err := newSelfCertificateSecret(ctx, r, hawtio, servingSecretName, hawtio.Namespace)
 		if err != nil {
+			r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+				"Failed to create the serving certificate in secret %s: %v", servingSecretName, err)
 			return nil, 0, err
 		}
 
@@ -109,10 +142,14 @@ func kubeCreateServingCertificate(ctx context.Context, r *ReconcileHawtio, hawti
 		}
 		_, err = r.coreClient.Secrets(hawtio.Namespace).Create(ctx, servingCertSecret, metav1.CreateOptions{})
 		if err != nil {
+			r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+				"Failed to create the serving certificate in secret %s: %v", servingSecretName, err)
 			return nil, 0, errs.Wrap(err, "Creating the serving certificate secret failed")
 		}
 
 		conKLog.Info("Serving certificate created successfully", "secret", servingSecretName)
+		r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonCertificateCreated,
+			"Created the serving certificate in secret %s", servingSecretName)
 		// New Secret so maximum expiry period
 		return servingCertSecret, certificateExpiryPeriod(hawtio), nil
 	}
@@ -18,6 +18,12 @@ import (
 
 var conOsLog = logf.Log.WithName("controller_hawtio_openshift")
 
+const (
+	// The ConfigMap, published by OpenShift in every namespace, with the service CA bundle
+	openShiftServiceCAConfigMap = "openshift-service-ca.crt"
+	openShiftServiceCAKey       = "service-ca.crt"
+)
+
 func newSignedCertificateSecret(ctx context.Context, r *ReconcileHawtio, hawtio *hawtiov2.Hawtio, name string, namespace string) (*corev1.Secret, error) {
 	caSecret, err := r.coreClient.Secrets("openshift-service-ca").Get(ctx, "signing-key", metav1.GetOptions{})
 	if err != nil {
@@ -67,7 +73,7 @@ func osCreateClientCertificate(ctx context.Context, r *ReconcileHawtio, hawtio *
 			// This a legacy certificate so adopt it
 			// Note: adoptLegacyResource returns the Sentinel Error (ErrLegacyResourceAdopted)
 			// on success.
-			adoptErr := r.adoptLegacyResource(ctx, clientCertSecret)
+			adoptErr := r.adoptLegacyResource(ctx, hawtio, clientCertSecret)
 			if adoptErr != nil {
 				// Returns ErrLegacyResourceAdopted (to requeue) or a real API error
 				return nil, 0, adoptErr
@@ -76,15 +82,18 @@ func osCreateClientCertificate(ctx context.Context, r *ReconcileHawtio, hawtio *
 
 		//
 		// Check the secret's certificate validity.
-		// Is the secret certificate invalid (expired).
+		// Is the secret certificate invalid (expired) or outdated (its
+		// key algorithm changed).
 		// If so they need to update it with a new certificate.
 		//
 		expiryIn := checkCertificateExpiry(hawtio, clientCertSecret, r.logger)
-		if expiryIn == 0 {
-			// certificate is invalid or close to expiring
+		if expiryIn == 0 || isCertificateOutdated(hawtio, clientCertSecret, nil) {
+			// certificate is invalid, outdated or close to expiring
 			// create a new one and update the secret
 			newSecret, err := newSignedCertificateSecret(ctx, r, hawtio, clientCertSecret.Name, clientCertSecret.Namespace)
 			if err != nil {
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+					"Failed to rotate the client certificate in secret %s: %v", clientCertSecret.Name, err)
 				return nil, 0, err
 			}
 
@@ -99,9 +108,14 @@ func osCreateClientCertificate(ctx context.Context, r *ReconcileHawtio, hawtio *
 
 			// Commit the update
 			if err := r.client.Update(ctx, clientCertSecret); err != nil {
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+					"Failed to rotate the client certificate in secret %s: %v", clientCertSecret.Name, err)
 				return nil, 0, err
 			}
 
+			r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonCertificateRotated,
+				"Rotated the client certificate in secret %s", clientCertSecret.Name)
+
 			// reset expiryIn to maximum as new certificate
 			expiryIn = certificateExpiryPeriod(hawtio)
 		}
@@ -114,6 +128,8 @@ func osCreateClientCertificate(ctx context.Context, r *ReconcileHawtio, hawtio *
 
 		clientCertSecret, err := newSignedCertificateSecret(ctx, r, hawtio, clientSecretName, hawtio.Namespace)
 		if err != nil {
+			r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+				"Failed to create the client certificate in secret %s: %v", clientSecretName, err)
 			return nil, 0, err
 		}
 
@@ -122,11 +138,16 @@ func osCreateClientCertificate(ctx context.Context, r *ReconcileHawtio, hawtio *
 			return nil, 0, err
 		}
 		clientCertSecret, err = r.coreClient.Secrets(hawtio.Namespace).Create(ctx, clientCertSecret, metav1.CreateOptions{})
-		conOsLog.Info("Client certificate created successfully", "secret", clientSecretName, "Resource Version", clientCertSecret.GetResourceVersion())
 		if err != nil {
+			r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonCertificateFailed,
+				"Failed to create the client certificate in secret %s: %v", clientSecretName, err)
 			return nil, 0, errs.Wrap(err, "Creating the client certificate secret failed")
 		}
 
+		conOsLog.Info("Client certificate created successfully", "secret", clientSecretName, "Resource Version", clientCertSecret.GetResourceVersion())
+		r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonCertificateCreated,
+			"Created the client certificate in secret %s", clientSecretName)
+
 		// New Secret so maximum expiry period
 		return clientCertSecret, certificateExpiryPeriod(hawtio), nil
 	}
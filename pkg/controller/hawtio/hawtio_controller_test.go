@@ -2,22 +2,42 @@ package hawtio
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 	"github.com/hawtio/hawtio-operator/pkg/resources"
+	kresources "github.com/hawtio/hawtio-operator/pkg/resources/kubernetes"
+	oresources "github.com/hawtio/hawtio-operator/pkg/resources/openshift"
+	"github.com/hawtio/hawtio-operator/pkg/updater"
+	"github.com/hawtio/hawtio-operator/pkg/util"
 )
 
 func TestNonWatchedResourceNameNotFound(t *testing.T) {
@@ -279,6 +299,30 @@ func TestHawtioController_Reconcile(t *testing.T) {
 						},
 					})
 				})
+				t.Run("check if the status conditions have been set", func(t *testing.T) {
+					hawtio := hawtiov2.NewHawtio()
+					err = r.client.Get(context.TODO(), NamespacedName, hawtio)
+					require.NoError(t, err)
+
+					assert.Equal(t, hawtio.Generation, hawtio.Status.ObservedGeneration)
+
+					expected := map[string]metav1.ConditionStatus{
+						hawtiov2.HawtioConditionReady:             metav1.ConditionFalse,
+						hawtiov2.HawtioConditionProgressing:       metav1.ConditionTrue,
+						hawtiov2.HawtioConditionDegraded:          metav1.ConditionFalse,
+						hawtiov2.HawtioConditionRBACConfigValid:   metav1.ConditionTrue,
+						hawtiov2.HawtioConditionVersionSupported:  metav1.ConditionTrue,
+						hawtiov2.HawtioConditionCertificatesValid: metav1.ConditionTrue,
+						hawtiov2.HawtioConditionIngressReady:      metav1.ConditionFalse,
+					}
+					assert.Len(t, hawtio.Status.Conditions, len(expected))
+					for conditionType, status := range expected {
+						condition := meta.FindStatusCondition(hawtio.Status.Conditions, conditionType)
+						require.NotNil(t, condition, conditionType)
+						assert.Equal(t, status, condition.Status, conditionType)
+						assert.Equal(t, hawtio.Generation, condition.ObservedGeneration, conditionType)
+					}
+				})
 				t.Run("check if the environment variables have been set", func(t *testing.T) {
 					deployment := appsv1.Deployment{}
 					err = r.client.Get(context.TODO(), NamespacedName, &deployment)
@@ -294,3 +338,920 @@ func TestHawtioController_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestHawtioController_ReconcileMissingRBACConfigMap(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.RBAC.ConfigMap = "missing-rbac"
+	r, request := newTestReconcile(t, hawtio)
+
+	// Created phase
+	res, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err, "reconcile Error")
+	assert.Equal(t, reconcile.Result{Requeue: true}, res)
+
+	// Polling for the RBAC ConfigMap
+	res, err = r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err, "reconcile Error")
+	assert.Equal(t, reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, res)
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionRBACConfigValid)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonConfigMapNotFound, condition.Reason)
+
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, hawtiov2.HawtioConditionReady))
+}
+
+func TestHawtioController_ReconcileUnsupportedVersion(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Version = "0.1"
+
+	r, request := newTestReconcile(t, hawtio)
+
+	// Created and initialized phases, then deployed with the release the operator is built for
+	reconcileN(t, r, request, 3)
+
+	updated := hawtiov2.NewHawtio()
+	err := r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionVersionSupported)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonUnsupportedVersion, condition.Reason)
+	assert.Contains(t, condition.Message, `unsupported version "0.1"`)
+
+	assert.Equal(t, []string{"Warning " + EventReasonUnsupportedVersion + ` unsupported version "0.1", expected one of 2.2, 2.3, 3.0, using the release the operator is built for`},
+		filterEvents(drainEvents(r), EventReasonUnsupportedVersion))
+
+	deployment := resources.NewDefaultDeployment(hawtio)
+	err = r.client.Get(context.TODO(), client.ObjectKeyFromObject(deployment), deployment)
+	assert.NoError(t, err)
+
+	// The warning is not repeated while the version stays unsupported
+	reconcileN(t, r, request, 2)
+	assert.Empty(t, filterEvents(drainEvents(r), EventReasonUnsupportedVersion))
+}
+
+// newTestReconcile returns a reconciler whose fake client holds the Hawtio CR, and the
+// other given objects, along with the request to reconcile the CR
+func newTestReconcile(t *testing.T, hawtio *hawtiov2.Hawtio, objs ...client.Object) (*ReconcileHawtio, reconcile.Request) {
+	logf.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	r := buildReconcileWithFakeClientWithMocks(append([]client.Object{hawtio}, objs...), t)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: hawtio.Name, Namespace: hawtio.Namespace}}
+	return r, request
+}
+
+// reconcileN reconciles the request n times, eg. 3 times for the CR to go
+// through the created, initialized and deployed phases
+func reconcileN(t *testing.T, r *ReconcileHawtio, request reconcile.Request, n int) {
+	t.Helper()
+	for range n {
+		_, err := r.Reconcile(context.TODO(), request)
+		require.NoError(t, err, "reconcile Error")
+	}
+}
+
+// updateAndReconcile applies the given change to the Hawtio CR, reconciles it
+// once, and returns the CR as updated by the reconciliation
+func updateAndReconcile(t *testing.T, r *ReconcileHawtio, request reconcile.Request, mutate func(hawtio *hawtiov2.Hawtio)) *hawtiov2.Hawtio {
+	t.Helper()
+	hawtio := hawtiov2.NewHawtio()
+	err := r.client.Get(context.TODO(), request.NamespacedName, hawtio)
+	require.NoError(t, err)
+	mutate(hawtio)
+	err = r.client.Update(context.TODO(), hawtio)
+	require.NoError(t, err)
+
+	reconcileN(t, r, request, 1)
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, hawtio)
+	require.NoError(t, err)
+	return hawtio
+}
+
+// assertRemoved asserts the resource of the given key no longer exists
+func assertRemoved(t *testing.T, r *ReconcileHawtio, key client.ObjectKey, obj client.Object) {
+	t.Helper()
+	err := r.client.Get(context.TODO(), key, obj)
+	assert.True(t, kerrors.IsNotFound(err), "%T %s should have been removed", obj, key.Name)
+}
+
+// drainEvents returns the events recorded so far by the fake recorder
+func drainEvents(r *ReconcileHawtio) []string {
+	var events []string
+	recorder := r.recorder.(*record.FakeRecorder)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// filterEvents returns the recorded events with the given reason
+func filterEvents(events []string, reason string) []string {
+	return slices.DeleteFunc(events, func(event string) bool {
+		return !strings.Contains(event, " "+reason+" ")
+	})
+}
+
+func TestHawtioController_ReconcileEvents(t *testing.T) {
+	hawtio := initHawtio(1)
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	events := drainEvents(r)
+	assert.Contains(t, events, fmt.Sprintf("%s %s Created the serving certificate in secret %s-tls-serving",
+		corev1.EventTypeNormal, EventReasonCertificateCreated, hawtio.Name))
+}
+
+func TestAdoptLegacyResourceEvent(t *testing.T) {
+	hawtio := initHawtio(-1)
+	legacyConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+
+	r, _ := newTestReconcile(t, hawtio, legacyConfigMap)
+	r.logger = hawtioLogger
+
+	err := r.adoptLegacyResource(context.TODO(), hawtio, resources.NewDefaultConfigMap(hawtio))
+	assert.Equal(t, ErrLegacyResourceAdopted, err)
+
+	adopted := &corev1.ConfigMap{}
+	err = r.client.Get(context.TODO(), client.ObjectKeyFromObject(legacyConfigMap), adopted)
+	require.NoError(t, err)
+	assert.Equal(t, resources.LabelAppValue, adopted.Labels[resources.LabelAppKey])
+
+	events := drainEvents(r)
+	assert.Equal(t, []string{
+		fmt.Sprintf("%s %s Adopted legacy ConfigMap %s by adding the missing %s label",
+			corev1.EventTypeNormal, EventReasonLegacyResourceAdopted, hawtio.Name, resources.LabelAppKey),
+	}, events)
+}
+
+func TestHawtioController_ReconcileDoesNotMutateSpec(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Type = ""
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	updated := hawtiov2.NewHawtio()
+	err := r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	assert.Equal(t, hawtio.Spec, updated.Spec)
+
+	// The deployment is reconciled with the defaulted type
+	deployment := appsv1.Deployment{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, &deployment)
+	require.NoError(t, err)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  resources.HawtioTypeEnvVar,
+		Value: strings.ToLower(string(hawtiov2.ClusterHawtioDeploymentType)),
+	})
+}
+
+func TestHawtioController_ReconcilePodDisruptionBudget(t *testing.T) {
+	hawtio := initHawtio(-1)
+	replicas := int32(3)
+	hawtio.Spec.Replicas = &replicas
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, pdb)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), pdb.Spec.MaxUnavailable.IntVal)
+	assert.Equal(t, resources.LabelsForHawtio(hawtio.Name), pdb.Spec.Selector.MatchLabels)
+	assert.True(t, metav1.IsControlledBy(pdb, hawtio))
+
+	// Scaling down to a single replica removes the budget
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		replicas = 1
+		updated.Spec.Replicas = &replicas
+	})
+
+	assertRemoved(t, r, request.NamespacedName, pdb)
+}
+
+func TestHawtioController_ReconcilePodTemplate(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.PodTemplate.Online.Env = []corev1.EnvVar{
+		{Name: "EXTRA", Value: "extra"},
+		{Name: resources.HawtioTypeEnvVar, Value: "cluster"},
+	}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	updated := hawtiov2.NewHawtio()
+	err := r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionPodTemplateApplied)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonPodTemplateConflicts, condition.Reason)
+	assert.Contains(t, condition.Message, "environment variable "+resources.HawtioTypeEnvVar)
+	assert.Equal(t, []string{corev1.EventTypeWarning + " " + EventReasonPodTemplateIgnored + " " + condition.Message},
+		filterEvents(drainEvents(r), EventReasonPodTemplateIgnored))
+
+	// The warning is not repeated while the conflict remains
+	reconcileN(t, r, request, 2)
+	assert.Empty(t, filterEvents(drainEvents(r), EventReasonPodTemplateIgnored))
+
+	// Dropping the conflicting addition applies the pod template
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.PodTemplate.Online.Env = updated.Spec.PodTemplate.Online.Env[:1]
+	})
+	condition = meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionPodTemplateApplied)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// Removing the pod template removes the condition
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.PodTemplate = hawtiov2.HawtioPodTemplate{}
+	})
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionPodTemplateApplied))
+}
+
+func TestHawtioController_ReconcileNetworkPolicy(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.NetworkPolicy = hawtiov2.HawtioNetworkPolicy{
+		Enabled:                    true,
+		IngressControllerNamespace: "traefik",
+	}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	np := &networkingv1.NetworkPolicy{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, np)
+	require.NoError(t, err)
+	assert.Equal(t, resources.LabelsForHawtio(hawtio.Name), np.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, "traefik", np.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"])
+	assert.True(t, metav1.IsControlledBy(np, hawtio))
+
+	// Disabling the policy removes it
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.NetworkPolicy.Enabled = false
+	})
+
+	assertRemoved(t, r, request.NamespacedName, np)
+}
+
+func TestHawtioController_ReconcileHorizontalPodAutoscaler(t *testing.T) {
+	hawtio := initHawtio(-1)
+	minReplicas := int32(2)
+	hawtio.Spec.Autoscaling = hawtiov2.HawtioAutoscaling{
+		Enabled:     true,
+		MinReplicas: &minReplicas,
+		MaxReplicas: 4,
+	}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, hpa)
+	require.NoError(t, err)
+	assert.Equal(t, "Hawtio", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, hawtio.Name, hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+	assert.True(t, metav1.IsControlledBy(hpa, hawtio))
+
+	// The deployment is scaled to the minimum replicas until the autoscaler scales the CR
+	deployment := &appsv1.Deployment{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, deployment)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	// Disabling autoscaling removes the autoscaler
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Autoscaling.Enabled = false
+	})
+
+	assertRemoved(t, r, request.NamespacedName, hpa)
+}
+
+func TestHawtioController_ReconcileHTTPRoute(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.HTTPRoute = hawtiov2.HawtioHTTPRoute{
+		ParentRef: hawtiov2.HawtioGatewayReference{Name: "public", Namespace: "gateways"},
+	}
+	hostname := gatewayv1.Hostname("hawtio.example.com")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "gateways"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "https", Hostname: &hostname, Port: 443, Protocol: gatewayv1.HTTPSProtocolType},
+			},
+		},
+	}
+
+	r, request := newTestReconcile(t, hawtio, gateway)
+	r.apiSpec.GatewayAPI = true
+	r.apiSpec.BackendTLSPolicy = true
+
+	reconcileN(t, r, request, 3)
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, httpRoute)
+	require.NoError(t, err)
+	assert.Equal(t, gatewayv1.ObjectName("public"), httpRoute.Spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayv1.PortNumber(resources.SSLServicePort), *httpRoute.Spec.Rules[0].BackendRefs[0].Port)
+	assert.True(t, metav1.IsControlledBy(httpRoute, hawtio))
+
+	// The Gateway re-encrypts the traffic to the SSL service
+	policy := &gatewayv1.BackendTLSPolicy{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, policy)
+	require.NoError(t, err)
+	assert.Equal(t, gatewayv1.ObjectName(hawtio.Name), policy.Spec.TargetRefs[0].Name)
+	assert.True(t, metav1.IsControlledBy(policy, hawtio))
+
+	caConfigMap := kresources.NewDefaultBackendCAConfigMap(hawtio)
+	err = r.client.Get(context.TODO(), client.ObjectKeyFromObject(caConfigMap), caConfigMap)
+	require.NoError(t, err)
+	assert.NotEmpty(t, caConfigMap.Data[kresources.BackendCACertificateKey])
+
+	// The Gateway validates the serving certificate against the service host name
+	block, _ := pem.Decode([]byte(caConfigMap.Data[kresources.BackendCACertificateKey]))
+	require.NotNil(t, block)
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, gatewayv1.PreciseHostname(hawtio.Name+"."+hawtio.Namespace+".svc"), policy.Spec.Validation.Hostname)
+	assert.Contains(t, certificate.DNSNames, string(policy.Spec.Validation.Hostname))
+
+	// The HTTPRoute replaces the ingress
+	assertRemoved(t, r, request.NamespacedName, &networkingv1.Ingress{})
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hawtio.example.com", updated.Status.URL)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionHTTPRouteAccepted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonAcceptancePending, condition.Reason)
+
+	// No longer referencing the Gateway restores the ingress
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.HTTPRoute = hawtiov2.HawtioHTTPRoute{}
+	})
+
+	assertRemoved(t, r, request.NamespacedName, httpRoute)
+	assertRemoved(t, r, request.NamespacedName, policy)
+	assertRemoved(t, r, client.ObjectKeyFromObject(caConfigMap), caConfigMap)
+	err = r.client.Get(context.TODO(), request.NamespacedName, &networkingv1.Ingress{})
+	assert.NoError(t, err)
+}
+
+func TestHawtioController_ReconcileService(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Service = hawtiov2.HawtioService{
+		Type:            corev1.ServiceTypeNodePort,
+		Annotations:     map[string]string{"example.com/owner": "team-a"},
+		SessionAffinity: corev1.ServiceAffinityClientIP,
+	}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	service := &corev1.Service{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, service)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, service.Spec.SessionAffinity)
+	assert.Equal(t, "team-a", service.Annotations["example.com/owner"])
+
+	// The allocated node port is preserved
+	service.Spec.Ports[0].NodePort = 30443
+	err = r.client.Update(context.TODO(), service)
+	require.NoError(t, err)
+
+	reconcileN(t, r, request, 1)
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, service)
+	require.NoError(t, err)
+	assert.Equal(t, int32(30443), service.Spec.Ports[0].NodePort)
+
+	// The annotations removed from the spec are removed from the service,
+	// while those set by others are retained
+	service.Annotations["example.com/controller"] = "cloud"
+	err = r.client.Update(context.TODO(), service)
+	require.NoError(t, err)
+
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Service.Annotations = nil
+	})
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, service)
+	require.NoError(t, err)
+	assert.NotContains(t, service.Annotations, "example.com/owner")
+	assert.Equal(t, "cloud", service.Annotations["example.com/controller"])
+}
+
+func TestHawtioController_ReconcileIngress(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Ingress = hawtiov2.HawtioIngress{
+		ClassName:   "nginx",
+		Hosts:       []string{"hawtio.example.com"},
+		TLSSecret:   corev1.LocalObjectReference{Name: "hawtio-tls"},
+		Annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-read-timeout": "60"},
+	}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	ingress := &networkingv1.Ingress{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, ingress)
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "hawtio.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "hawtio-tls", ingress.Spec.TLS[0].SecretName)
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hawtio.example.com/", updated.Status.URL)
+
+	// The NGINX and removed annotations are removed once the ingress class changes
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Ingress.ClassName = "traefik"
+		updated.Spec.Ingress.Annotations = nil
+	})
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, ingress)
+	require.NoError(t, err)
+	assert.Equal(t, "traefik", *ingress.Spec.IngressClassName)
+	for key := range ingress.Annotations {
+		assert.NotContains(t, key, "nginx.ingress.kubernetes.io/")
+	}
+}
+
+func TestHawtioController_ReconcileAccess(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8"}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	ingress := &networkingv1.Ingress{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, ingress)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.0/8", ingress.Annotations[kresources.NginxWhitelistSourceRangeAnnotation])
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionAccessRestricted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// The node ports of the service are not restricted
+	drainEvents(r)
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Service.Type = corev1.ServiceTypeNodePort
+	})
+	condition = meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionAccessRestricted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonSourceRangesNotEnforced, condition.Reason)
+	assert.Contains(t, condition.Message, "node ports")
+	assert.Equal(t, []string{corev1.EventTypeWarning + " " + EventReasonSourceRangesIgnored + " " + condition.Message},
+		filterEvents(drainEvents(r), EventReasonSourceRangesIgnored))
+
+	// The warning is not repeated while the ranges stay ignored
+	reconcileN(t, r, request, 2)
+	assert.Empty(t, filterEvents(drainEvents(r), EventReasonSourceRangesIgnored))
+
+	// Removing the allowed source ranges lifts the restriction
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Access = hawtiov2.HawtioAccess{}
+	})
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, ingress)
+	require.NoError(t, err)
+	assert.NotContains(t, ingress.Annotations, kresources.NginxWhitelistSourceRangeAnnotation)
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionAccessRestricted))
+}
+
+func TestHawtioController_ReconcileServiceAccount(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Exposure.Type = hawtiov2.IngressHawtioExposureType
+	r, request := newTestReconcile(t, hawtio)
+
+	_, err := r.reconcileServiceAccount(context.TODO(), hawtio)
+	require.NoError(t, err)
+
+	// The redirect URIs are those the console is exposed at in this pass
+	_, err = r.reconcileOAuthRedirects(context.TODO(), hawtio, []string{"https://hawtio.example.com/", "https://console.example.com/"})
+	require.NoError(t, err)
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, serviceAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hawtio.example.com/", serviceAccount.Annotations[resources.OAuthRedirectURIAnnotationPrefix+resources.GetRedirectURIName(hawtio, "https://hawtio.example.com/")])
+	assert.Equal(t, "https://console.example.com/", serviceAccount.Annotations[resources.OAuthRedirectURIAnnotationPrefix+resources.GetRedirectURIName(hawtio, "https://console.example.com/")])
+
+	serviceAccount.Annotations["team"] = "console"
+	err = r.client.Update(context.TODO(), serviceAccount)
+	require.NoError(t, err)
+
+	// The redirect URIs of the hosts no longer exposed are removed,
+	// while the annotations set by others are retained
+	_, err = r.reconcileServiceAccount(context.TODO(), hawtio)
+	require.NoError(t, err)
+	_, err = r.reconcileOAuthRedirects(context.TODO(), hawtio, []string{"https://hawtio.example.com/"})
+	require.NoError(t, err)
+
+	err = r.client.Get(context.TODO(), request.NamespacedName, serviceAccount)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hawtio.example.com/", serviceAccount.Annotations[resources.OAuthRedirectURIAnnotationPrefix+resources.GetRedirectURIName(hawtio, "https://hawtio.example.com/")])
+	assert.NotContains(t, serviceAccount.Annotations, resources.OAuthRedirectURIAnnotationPrefix+resources.GetRedirectURIName(hawtio, "https://console.example.com/"))
+	assert.Equal(t, "console", serviceAccount.Annotations["team"])
+}
+
+func TestHawtioController_ReconcileRouteRegeneration(t *testing.T) {
+	hawtio := initHawtio(-1)
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	// A route with a host that is not generated, on OpenShift
+	t.Setenv(HawtioUnderTestEnvVar, "true")
+	route := oresources.NewDefaultRoute(hawtio)
+	route.Spec.Host = hawtio.Spec.RouteHostName
+	err := controllerutil.SetControllerReference(hawtio, route, r.scheme)
+	require.NoError(t, err)
+	err = r.client.Create(context.TODO(), route)
+	require.NoError(t, err)
+	r.apiSpec.IsOpenShift4 = true
+	r.apiSpec.Routes = true
+
+	// Clearing the host name deletes the route for regeneration,
+	// while the deployment is still reconciled in the same pass
+	updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		replicas := int32(2)
+		updated.Spec.RouteHostName = ""
+		updated.Spec.Replicas = &replicas
+	})
+
+	assertRemoved(t, r, request.NamespacedName, route)
+
+	deployment := &appsv1.Deployment{}
+	err = r.client.Get(context.TODO(), request.NamespacedName, deployment)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+}
+
+func TestHawtioController_ReconcileHosts(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Ingress.Hosts = []string{"hawtio.example.com"}
+	hawtio.Spec.Hosts = []string{"console.example.com"}
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	// The ingress serves the additional host names
+	ingress := &networkingv1.Ingress{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, ingress)
+	require.NoError(t, err)
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[1].Host)
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hawtio.example.com/", updated.Status.URL)
+	assert.Equal(t, []string{"https://hawtio.example.com/", "https://console.example.com/"}, updated.Status.URLs)
+
+	// On OpenShift, a route is created for each additional host name
+	r.apiSpec.IsOpenShift4 = true
+	r.apiSpec.Routes = true
+
+	aliasRoutes, _, err := r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	require.Len(t, aliasRoutes, 1)
+
+	aliasRoute := &routev1.Route{}
+	aliasName := types.NamespacedName{Name: util.GetAliasRouteName(hawtio, "console.example.com"), Namespace: hawtio.Namespace}
+	err = r.client.Get(context.TODO(), aliasName, aliasRoute)
+	require.NoError(t, err)
+	assert.Equal(t, "console.example.com", aliasRoute.Spec.Host)
+	assert.True(t, metav1.IsControlledBy(aliasRoute, updated))
+
+	// The router shard labels removed from the spec are removed from the route,
+	// while those set by others are retained
+	updated.Spec.Route.Labels = map[string]string{"router": "shard-a"}
+	_, _, err = r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	err = r.client.Get(context.TODO(), aliasName, aliasRoute)
+	require.NoError(t, err)
+	assert.Equal(t, "shard-a", aliasRoute.Labels["router"])
+
+	aliasRoute.Labels["team"] = "console"
+	err = r.client.Update(context.TODO(), aliasRoute)
+	require.NoError(t, err)
+
+	updated.Spec.Route.Labels = nil
+	_, _, err = r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	err = r.client.Get(context.TODO(), aliasName, aliasRoute)
+	require.NoError(t, err)
+	assert.NotContains(t, aliasRoute.Labels, "router")
+	assert.Equal(t, "console", aliasRoute.Labels["team"])
+
+	// Removing a host name deletes its route, while the routes of
+	// the other host names are retained rather than renamed
+	updated.Spec.Hosts = []string{"console.example.com", "admin.example.com"}
+	aliasRoutes, _, err = r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	require.Len(t, aliasRoutes, 2)
+
+	adminRoute := &routev1.Route{}
+	adminName := types.NamespacedName{Name: util.GetAliasRouteName(hawtio, "admin.example.com"), Namespace: hawtio.Namespace}
+	err = r.client.Get(context.TODO(), adminName, adminRoute)
+	require.NoError(t, err)
+
+	updated.Spec.Hosts = []string{"admin.example.com"}
+	aliasRoutes, _, err = r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	require.Len(t, aliasRoutes, 1)
+	assert.Equal(t, adminName.Name, aliasRoutes[0].Name)
+	assert.Equal(t, adminRoute.UID, aliasRoutes[0].UID)
+
+	assertRemoved(t, r, aliasName, aliasRoute)
+
+	updated.Spec.Hosts = nil
+	aliasRoutes, _, err = r.reconcileAliasRoutes(context.TODO(), updated, DeploymentConfiguration{})
+	require.NoError(t, err)
+	assert.Empty(t, aliasRoutes)
+
+	assertRemoved(t, r, adminName, adminRoute)
+}
+
+func TestHawtioController_ReconcileExposure(t *testing.T) {
+	hawtio := initHawtio(-1)
+	r, request := newTestReconcile(t, hawtio)
+
+	reconcileN(t, r, request, 3)
+
+	// Exposed with an ingress by default on Kubernetes
+	err := r.client.Get(context.TODO(), request.NamespacedName, &networkingv1.Ingress{})
+	require.NoError(t, err)
+
+	updated := hawtiov2.NewHawtio()
+	err = r.client.Get(context.TODO(), request.NamespacedName, updated)
+	require.NoError(t, err)
+	assert.NotEmpty(t, updated.Status.URL)
+
+	// An internal-only console is not exposed
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Exposure.Type = hawtiov2.NoneHawtioExposureType
+	})
+
+	assertRemoved(t, r, request.NamespacedName, &networkingv1.Ingress{})
+	err = r.client.Get(context.TODO(), request.NamespacedName, &corev1.Service{})
+	assert.NoError(t, err)
+	assert.Empty(t, updated.Status.URL)
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionIngressReady))
+
+	// Routes are not supported on Kubernetes
+	updated = updateAndReconcile(t, r, request, func(updated *hawtiov2.Hawtio) {
+		updated.Spec.Exposure.Type = hawtiov2.RouteHawtioExposureType
+	})
+
+	condition := meta.FindStatusCondition(updated.Status.Conditions, hawtiov2.HawtioConditionRouteAdmitted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonRoutesUnavailable, condition.Reason)
+	assertRemoved(t, r, request.NamespacedName, &networkingv1.Ingress{})
+}
+
+func TestMergeManagedMetadata(t *testing.T) {
+	route := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"team": "console"},
+			Annotations: map[string]string{oresources.RouteIPWhitelistAnnotation: "10.0.0.0/8"},
+		},
+	}
+
+	mergeManagedMetadata(route, map[string]string{"router": "shard-a"}, map[string]string{"haproxy.router.openshift.io/timeout": "60s"}, oresources.RouteIPWhitelistAnnotation)
+
+	assert.Equal(t, map[string]string{"team": "console", "router": "shard-a"}, route.Labels)
+	assert.Equal(t, map[string]string{
+		"haproxy.router.openshift.io/timeout": "60s",
+		managedLabelsAnnotation:               "router",
+		managedAnnotationsAnnotation:          "haproxy.router.openshift.io/timeout",
+	}, route.Annotations)
+
+	// The keys no longer set are removed, those set by others are retained
+	route.Annotations["openshift.io/host.generated"] = "true"
+	mergeManagedMetadata(route, map[string]string{}, nil, oresources.RouteIPWhitelistAnnotation)
+
+	assert.Equal(t, map[string]string{"team": "console"}, route.Labels)
+	assert.Equal(t, map[string]string{"openshift.io/host.generated": "true"}, route.Annotations)
+}
+
+func TestAddImageDigestsSkipsPinnedImages(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Images.Online = hawtiov2.HawtioImage{Tag: "2.3.0"}
+
+	r := buildReconcileWithFakeClientWithMocks([]client.Object{hawtio}, t)
+	r.updatePoller = &updater.RegistryPoller{}
+	r.ImageRepository = "quay.io/hawtio/online"
+	r.GatewayImageRepository = "quay.io/hawtio/online-gateway"
+
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: hawtio.Name + "-container", Image: "quay.io/hawtio/online:2.3.0"},
+		{Name: hawtio.Name + "-gateway-container", Image: "quay.io/hawtio/online-gateway:2.4.0"},
+	}
+
+	r.addImageDigests(hawtio, nil, deployment, "sha256:online", "sha256:gateway", logr.Discard())
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Equal(t, "quay.io/hawtio/online:2.3.0", containers[0].Image)
+	assert.Equal(t, "quay.io/hawtio/online-gateway@sha256:gateway", containers[1].Image)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, resources.OnlineDigestAnnotation)
+	assert.Equal(t, "sha256:gateway", deployment.Spec.Template.Annotations[resources.GatewayDigestAnnotation])
+}
+
+func TestAddImageDigestsSkipsVersionedImages(t *testing.T) {
+	hawtio := initHawtio(-1)
+	hawtio.Spec.Version = "3.0"
+
+	r := buildReconcileWithFakeClientWithMocks([]client.Object{hawtio}, t)
+	r.updatePoller = &updater.RegistryPoller{}
+	r.ImageRepository = "quay.io/hawtio/online"
+	r.GatewayImageRepository = "quay.io/hawtio/online-gateway"
+
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: hawtio.Name + "-container", Image: "quay.io/hawtio/online:3.0.0"},
+		{Name: hawtio.Name + "-gateway-container", Image: "quay.io/hawtio/online-gateway:3.0.0"},
+	}
+
+	release, err := resources.SelectOperandRelease(hawtio)
+	require.NoError(t, err)
+	r.addImageDigests(hawtio, release, deployment, "sha256:online", "sha256:gateway", logr.Discard())
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Equal(t, "quay.io/hawtio/online:3.0.0", containers[0].Image)
+	assert.Equal(t, "quay.io/hawtio/online-gateway:3.0.0", containers[1].Image)
+	assert.Empty(t, deployment.Spec.Template.Annotations)
+
+	// An unsupported version falls back to the images the operator is configured with,
+	// which are those the poller digests are for
+	hawtio.Spec.Version = "0.1"
+	release, err = resources.SelectOperandRelease(hawtio)
+	require.Error(t, err)
+	r.addImageDigests(hawtio, release, deployment, "sha256:online", "sha256:gateway", logr.Discard())
+
+	containers = deployment.Spec.Template.Spec.Containers
+	assert.Equal(t, "quay.io/hawtio/online@sha256:online", containers[0].Image)
+	assert.Equal(t, "quay.io/hawtio/online-gateway@sha256:gateway", containers[1].Image)
+}
+
+func TestIsDeploymentFailed(t *testing.T) {
+	hawtio := initHawtio(-1)
+	r := buildReconcileWithFakeClientWithMocks([]client.Object{hawtio}, t)
+
+	progressing := func(status corev1.ConditionStatus, reason string, since time.Duration) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{
+			{
+				Type:           appsv1.DeploymentProgressing,
+				Status:         status,
+				Reason:         reason,
+				LastUpdateTime: metav1.NewTime(time.Now().Add(-since)),
+			},
+		}
+		return deployment
+	}
+
+	assert.False(t, r.isDeploymentFailed(hawtio, &appsv1.Deployment{}))
+	assert.True(t, r.isDeploymentFailed(hawtio, progressing(corev1.ConditionFalse, "ProgressDeadlineExceeded", 0)))
+	assert.False(t, r.isDeploymentFailed(hawtio, progressing(corev1.ConditionTrue, "ReplicaSetUpdated", 5*time.Minute)))
+	assert.False(t, r.isDeploymentFailed(hawtio, progressing(corev1.ConditionTrue, "NewReplicaSetAvailable", time.Hour)))
+
+	// The deadline configured in the Hawtio CR is honoured
+	// before the deployment controller reports it
+	deadline := int32(60)
+	hawtio.Spec.Rollout.ProgressDeadlineSeconds = &deadline
+	assert.True(t, r.isDeploymentFailed(hawtio, progressing(corev1.ConditionTrue, "ReplicaSetUpdated", 5*time.Minute)))
+	assert.False(t, r.isDeploymentFailed(hawtio, progressing(corev1.ConditionTrue, "ReplicaSetUpdated", 30*time.Second)))
+}
+
+func TestGenerateSelfSignedCertSecret(t *testing.T) {
+	tests := []struct {
+		algorithm   hawtiov2.HawtioKeyAlgorithm
+		expected    hawtiov2.HawtioKeyAlgorithm
+		keyUsage    x509.KeyUsage
+		pemKeyBlock string
+	}{
+		{
+			expected:    hawtiov2.RSA2048HawtioKeyAlgorithm,
+			keyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			pemKeyBlock: "RSA PRIVATE KEY",
+		},
+		{
+			algorithm:   hawtiov2.RSA3072HawtioKeyAlgorithm,
+			expected:    hawtiov2.RSA3072HawtioKeyAlgorithm,
+			keyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			pemKeyBlock: "RSA PRIVATE KEY",
+		},
+		{
+			algorithm:   hawtiov2.ECDSAP256HawtioKeyAlgorithm,
+			expected:    hawtiov2.ECDSAP256HawtioKeyAlgorithm,
+			keyUsage:    x509.KeyUsageDigitalSignature,
+			pemKeyBlock: "EC PRIVATE KEY",
+		},
+		{
+			algorithm:   hawtiov2.ECDSAP384HawtioKeyAlgorithm,
+			expected:    hawtiov2.ECDSAP384HawtioKeyAlgorithm,
+			keyUsage:    x509.KeyUsageDigitalSignature,
+			pemKeyBlock: "EC PRIVATE KEY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.expected), func(t *testing.T) {
+			hawtio := initHawtio(-1)
+			hawtio.Spec.Auth.ClientCertKeyAlgorithm = tt.algorithm
+			hawtio.Spec.RouteHostName = ""
+			hawtio.Spec.Hosts = []string{"hawtio.example.com"}
+			dnsNames := servingCertificateDNSNames(hawtio)
+
+			secret, err := generateSelfSignedCertSecret(hawtio, "hawtio-online-tls-serving", hawtio.Namespace,
+				"hawtio-online.hawtio.svc", dnsNames, time.Now().AddDate(1, 0, 0))
+			require.NoError(t, err)
+
+			block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+			require.NotNil(t, block)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{
+				hawtio.Name,
+				hawtio.Name + "." + hawtio.Namespace,
+				hawtio.Name + "." + hawtio.Namespace + ".svc",
+				hawtio.Name + "." + hawtio.Namespace + ".svc.cluster.local",
+				"hawtio.example.com",
+			}, cert.DNSNames)
+			assert.NoError(t, cert.VerifyHostname(hawtio.Name+"."+hawtio.Namespace+".svc"))
+			assert.Positive(t, cert.SerialNumber.Sign())
+			assert.True(t, cert.BasicConstraintsValid)
+			assert.False(t, cert.IsCA)
+			assert.Equal(t, tt.keyUsage, cert.KeyUsage)
+			assert.Equal(t, tt.expected, getKeyAlgorithm(cert.PublicKey))
+
+			keyBlock, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+			require.NotNil(t, keyBlock)
+			assert.Equal(t, tt.pemKeyBlock, keyBlock.Type)
+			_, err = parsePrivateKey(keyBlock.Bytes)
+			assert.NoError(t, err)
+
+			assert.False(t, isCertificateOutdated(hawtio, secret, dnsNames))
+		})
+	}
+}
+
+func TestIsCertificateOutdated(t *testing.T) {
+	hawtio := initHawtio(-1)
+	dnsNames := servingCertificateDNSNames(hawtio)
+
+	secret, err := generateSelfSignedCertSecret(hawtio, "hawtio-online-tls-serving", hawtio.Namespace,
+		"hawtio-online.hawtio.svc", dnsNames, time.Now().AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.False(t, isCertificateOutdated(hawtio, secret, dnsNames))
+
+	// A host name the console is exposed at is added
+	hawtio.Spec.Hosts = []string{"hawtio.example.com"}
+	assert.True(t, isCertificateOutdated(hawtio, secret, servingCertificateDNSNames(hawtio)))
+
+	// The key algorithm is changed
+	hawtio.Spec.Hosts = nil
+	hawtio.Spec.Auth.ClientCertKeyAlgorithm = hawtiov2.ECDSAP256HawtioKeyAlgorithm
+	assert.True(t, isCertificateOutdated(hawtio, secret, dnsNames))
+
+	// A certificate without DNS names, as generated by former operator versions
+	secret, err = generateSelfSignedCertSecret(hawtio, "hawtio-online-tls-serving", hawtio.Namespace,
+		"hawtio-online.hawtio.svc", nil, time.Now().AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.True(t, isCertificateOutdated(hawtio, secret, dnsNames))
+	assert.False(t, isCertificateOutdated(hawtio, secret, nil))
+}
@@ -111,7 +111,12 @@ func (r *ReconcileHawtio) deletion(ctx context.Context, hawtio *hawtiov2.Hawtio,
 			if err != nil && !kerrors.IsNotFound(err) {
 				return fmt.Errorf("failed to get OAuth client: %v", err)
 			}
-			updated := resources.RemoveRedirectURIFromOauthClient(oc, hawtio.Status.URL)
+			updated := false
+			for _, url := range util.GetStatusURLs(hawtio) {
+				if resources.RemoveRedirectURIFromOauthClient(oc, url) {
+					updated = true
+				}
+			}
 			if updated {
 				err := r.client.Update(ctx, oc)
 				if err != nil {
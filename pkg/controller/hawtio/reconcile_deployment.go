@@ -3,9 +3,12 @@ package hawtio
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"github.com/go-logr/logr"
 
@@ -13,6 +16,9 @@ import (
 	"github.com/hawtio/hawtio-operator/pkg/resources"
 	"github.com/hawtio/hawtio-operator/pkg/util"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 )
 
 func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) (controllerutil.OperationResult, error) {
@@ -42,6 +48,11 @@ func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawti
 		}
 	}
 
+	// Whether the update poller digests differ from those already deployed
+	digestsChanged := false
+	// The additions of the pod template overridden by the operator
+	var podTemplateConflicts []string
+
 	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetDeployment, func() error {
 		// A read-only copy of the cluster state for diff logging
 		liveSnapshot := targetDeployment.DeepCopy()
@@ -67,6 +78,7 @@ func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawti
 			reqLogger.Error(err, "Error reconciling deployment")
 			return err
 		}
+		podTemplateConflicts = resources.PodTemplateConflicts(hawtio, &blueprint.Spec.Template.Spec)
 
 		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *appsv1.Deployment) {
 			// If hydration stripped required fields, patch them directly back from source
@@ -86,7 +98,12 @@ func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawti
 		// Assign the fully hydrated and patched blueprint spec
 		targetDeployment.Spec = serverBlueprint.Spec
 
-		r.addImageDigests(hawtio, targetDeployment, onlineDigest, gatewayDigest, reqLogger)
+		r.addImageDigests(hawtio, deploymentConfig.release, targetDeployment, onlineDigest, gatewayDigest, reqLogger)
+
+		liveAnnotations := liveSnapshot.Spec.Template.Annotations
+		targetAnnotations := targetDeployment.Spec.Template.Annotations
+		digestsChanged = liveAnnotations[resources.OnlineDigestAnnotation] != targetAnnotations[resources.OnlineDigestAnnotation] ||
+			liveAnnotations[resources.GatewayDigestAnnotation] != targetAnnotations[resources.GatewayDigestAnnotation]
 
 		// Report any known differences to the log (only if in debug log level)
 		util.ReportDiff("Deployment", liveSnapshot, targetDeployment)
@@ -98,7 +115,7 @@ func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawti
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, resources.NewDefaultDeployment(hawtio))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, resources.NewDefaultDeployment(hawtio))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -106,11 +123,42 @@ func (r *ReconcileHawtio) reconcileDeployment(ctx context.Context, hawtio *hawti
 		}
 	}
 
+	if err == nil && opResult == controllerutil.OperationResultUpdated && digestsChanged {
+		r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonImagesUpdated,
+			"Rolling out updated images with digests %s (online) and %s (gateway)", onlineDigest, gatewayDigest)
+	}
+
+	if err == nil {
+		r.reportPodTemplateConflicts(hawtio, podTemplateConflicts)
+	}
+
 	util.ReportResourceChange("Deployment", targetDeployment, opResult)
 	return opResult, err
 }
 
-func (r *ReconcileHawtio) addImageDigests(hawtio *hawtiov2.Hawtio, deployment *appsv1.Deployment, onlineDigest string, gatewayDigest string, logger logr.Logger) {
+// reportPodTemplateConflicts records whether the additions of the pod template are
+// all applied, as those conflicting with the volumes, volume mounts, environment
+// variables and containers managed by the operator are otherwise silently ignored.
+func (r *ReconcileHawtio) reportPodTemplateConflicts(hawtio *hawtiov2.Hawtio, conflicts []string) {
+	if reflect.DeepEqual(hawtio.Spec.PodTemplate, hawtiov2.HawtioPodTemplate{}) {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionPodTemplateApplied)
+		return
+	}
+
+	if len(conflicts) > 0 {
+		message := fmt.Sprintf("The pod template additions conflicting with those managed by the operator are ignored: %s", strings.Join(conflicts, ", "))
+		r.logger.Info(message)
+		if turnsFalse(&hawtio.Status, hawtiov2.HawtioConditionPodTemplateApplied) {
+			r.recorder.Event(hawtio, corev1.EventTypeWarning, EventReasonPodTemplateIgnored, message)
+		}
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionPodTemplateApplied, metav1.ConditionFalse, reasonPodTemplateConflicts, message)
+		return
+	}
+
+	setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionPodTemplateApplied, metav1.ConditionTrue, reasonPodTemplateApplied, "The pod template additions are applied")
+}
+
+func (r *ReconcileHawtio) addImageDigests(hawtio *hawtiov2.Hawtio, release *resources.OperandRelease, deployment *appsv1.Deployment, onlineDigest string, gatewayDigest string, logger logr.Logger) {
 	logger.V(util.DebugLogLevel).Info("Adding Update Poller digests to deployment", "onlineDigest", onlineDigest, "gatewayDigest", gatewayDigest)
 
 	if r.updatePoller == nil {
@@ -118,6 +166,11 @@ func (r *ReconcileHawtio) addImageDigests(hawtio *hawtiov2.Hawtio, deployment *a
 		return // nothing to do - no update poller initialized
 	}
 
+	if release != nil {
+		logger.V(util.DebugLogLevel).Info("Images selected by the operand release. No modifications to deployment")
+		return // the poller digests are those of the images the operator is configured with
+	}
+
 	if onlineDigest == "" || gatewayDigest == "" {
 		logger.V(util.DebugLogLevel).Info("Update Poller digests are empty. No modifications to deployment")
 		return // digests never populated so don't overwrite anything
@@ -129,9 +182,10 @@ func (r *ReconcileHawtio) addImageDigests(hawtio *hawtiov2.Hawtio, deployment *a
 	}
 
 	logger.V(util.DebugLogLevel).Info("Modifying deployment images with Update Poller digests")
-	// Loop through the containers in the Deployment
+	// Loop through the containers in the Deployment,
+	// leaving the images pinned by the Hawtio CR as is
 	for i, container := range deployment.Spec.Template.Spec.Containers {
-		if container.Name == hawtio.Name+"-container" && onlineDigest != "" {
+		if container.Name == hawtio.Name+"-container" && onlineDigest != "" && !resources.IsImagePinned(hawtio.Spec.Images.Online) {
 			// Track it in metadata
 			deployment.Spec.Template.Annotations[resources.OnlineDigestAnnotation] = onlineDigest
 
@@ -140,9 +194,107 @@ func (r *ReconcileHawtio) addImageDigests(hawtio *hawtiov2.Hawtio, deployment *a
 			deployment.Spec.Template.Spec.Containers[i].Image = r.ImageRepository + "@" + onlineDigest
 		}
 
-		if container.Name == hawtio.Name+"-gateway-container" && gatewayDigest != "" {
+		if container.Name == hawtio.Name+"-gateway-container" && gatewayDigest != "" && !resources.IsImagePinned(hawtio.Spec.Images.Gateway) {
 			deployment.Spec.Template.Annotations[resources.GatewayDigestAnnotation] = gatewayDigest
 			deployment.Spec.Template.Spec.Containers[i].Image = r.GatewayImageRepository + "@" + gatewayDigest
 		}
 	}
 }
+
+func (r *ReconcileHawtio) reconcilePodDisruptionBudget(ctx context.Context, hawtio *hawtiov2.Hawtio) (controllerutil.OperationResult, error) {
+	if !resources.IsPodDisruptionBudgetApplicable(hawtio) {
+		// Deleted, eg. once scaled down to a single replica, so that node drains are not blocked
+		return r.removeOwnedResource(ctx, hawtio, resources.NewDefaultPodDisruptionBudget(hawtio))
+	}
+
+	targetPDB := resources.NewDefaultPodDisruptionBudget(hawtio)
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetPDB, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := targetPDB.DeepCopy()
+
+		// Set the owner reference for garbage collection
+		if err := controllerutil.SetControllerReference(hawtio, targetPDB, r.scheme); err != nil {
+			return err
+		}
+
+		reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcilePodDisruptionBudget", hawtio.Name))
+		blueprint := resources.NewPodDisruptionBudget(hawtio, reqLogger)
+
+		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *policyv1.PodDisruptionBudget) {
+			// If hydration stripped required fields, patch them directly back from source
+			if hydrated.Spec.Selector == nil {
+				hydrated.Spec.Selector = source.Spec.Selector
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		targetPDB.Labels = util.MergeMap(targetPDB.Labels, blueprint.Labels)
+		targetPDB.Annotations = util.MergeMap(targetPDB.Annotations, blueprint.Annotations)
+		// Assign the fully hydrated and patched blueprint spec
+		targetPDB.Spec = serverBlueprint.Spec
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("PodDisruptionBudget", liveSnapshot, targetPDB)
+
+		return nil
+	})
+	if err != nil {
+		return opResult, err
+	}
+
+	util.ReportResourceChange("PodDisruptionBudget", targetPDB, opResult)
+	return opResult, nil
+}
+
+func (r *ReconcileHawtio) reconcileHorizontalPodAutoscaler(ctx context.Context, hawtio *hawtiov2.Hawtio) (controllerutil.OperationResult, error) {
+	if !resources.IsAutoscaled(hawtio) {
+		return r.removeOwnedResource(ctx, hawtio, resources.NewDefaultHorizontalPodAutoscaler(hawtio))
+	}
+
+	targetHPA := resources.NewDefaultHorizontalPodAutoscaler(hawtio)
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetHPA, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := targetHPA.DeepCopy()
+
+		// Set the owner reference for garbage collection
+		if err := controllerutil.SetControllerReference(hawtio, targetHPA, r.scheme); err != nil {
+			return err
+		}
+
+		reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcileHorizontalPodAutoscaler", hawtio.Name))
+		blueprint := resources.NewHorizontalPodAutoscaler(hawtio, reqLogger)
+
+		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *autoscalingv2.HorizontalPodAutoscaler) {
+			// If hydration stripped required fields, patch them directly back from source
+			if hydrated.Spec.ScaleTargetRef.Name == "" {
+				hydrated.Spec.ScaleTargetRef = source.Spec.ScaleTargetRef
+			}
+			if len(hydrated.Spec.Metrics) == 0 {
+				hydrated.Spec.Metrics = source.Spec.Metrics
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		targetHPA.Labels = util.MergeMap(targetHPA.Labels, blueprint.Labels)
+		targetHPA.Annotations = util.MergeMap(targetHPA.Annotations, blueprint.Annotations)
+		// Assign the fully hydrated and patched blueprint spec
+		targetHPA.Spec = serverBlueprint.Spec
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("HorizontalPodAutoscaler", liveSnapshot, targetHPA)
+
+		return nil
+	})
+	if err != nil {
+		return opResult, err
+	}
+
+	util.ReportResourceChange("HorizontalPodAutoscaler", targetHPA, opResult)
+	return opResult, nil
+}
@@ -3,6 +3,7 @@ package hawtio
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -10,8 +11,11 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
@@ -29,6 +33,7 @@ func (r *ReconcileHawtio) reconcileService(ctx context.Context, hawtio *hawtiov2
 		liveSnapshot := targetService.DeepCopy()
 		oldClusterIP := targetService.Spec.ClusterIP
 		oldClusterIPs := targetService.Spec.ClusterIPs
+		oldPorts := targetService.Spec.Ports
 
 		// Set the owner reference for garbage collection.
 		if err := controllerutil.SetControllerReference(hawtio, targetService, r.scheme); err != nil {
@@ -47,8 +52,19 @@ func (r *ReconcileHawtio) reconcileService(ctx context.Context, hawtio *hawtiov2
 				hydrated.Spec.Selector = source.Spec.Selector
 			}
 
+			// Node ports are only allocated to NodePort and LoadBalancer services.
+			// Ensure the node ports are not changed to new random ones from hydration
+			// of default, by preserving those of the existing service.
 			for i := range hydrated.Spec.Ports {
 				hydrated.Spec.Ports[i].NodePort = 0
+				if hydrated.Spec.Type == corev1.ServiceTypeClusterIP {
+					continue
+				}
+				for _, oldPort := range oldPorts {
+					if oldPort.Port == hydrated.Spec.Ports[i].Port && oldPort.Protocol == hydrated.Spec.Ports[i].Protocol {
+						hydrated.Spec.Ports[i].NodePort = oldPort.NodePort
+					}
+				}
 			}
 
 			// Ensure ClusterIP is not changed to a new random one from hydration of default.
@@ -66,8 +82,8 @@ func (r *ReconcileHawtio) reconcileService(ctx context.Context, hawtio *hawtiov2
 			return err
 		}
 
-		targetService.Labels = util.MergeMap(targetService.Labels, blueprint.Labels)
-		targetService.Annotations = util.MergeMap(targetService.Annotations, serverBlueprint.Annotations)
+		// The annotations, eg. of the cloud load balancer, may have been removed
+		mergeManagedMetadata(targetService, blueprint.Labels, serverBlueprint.Annotations)
 		// Assign the fully hydrated and patched blueprint spec
 		targetService.Spec = serverBlueprint.Spec
 
@@ -81,7 +97,7 @@ func (r *ReconcileHawtio) reconcileService(ctx context.Context, hawtio *hawtiov2
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, resources.NewDefaultService(hawtio))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, resources.NewDefaultService(hawtio))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -97,10 +113,64 @@ func (r *ReconcileHawtio) reconcileService(ctx context.Context, hawtio *hawtiov2
 	return opResult, nil
 }
 
+func (r *ReconcileHawtio) reconcileNetworkPolicy(ctx context.Context, hawtio *hawtiov2.Hawtio) (controllerutil.OperationResult, error) {
+	if !resources.IsNetworkPolicyApplicable(hawtio) {
+		// Deleted, eg. once disabled, so that the pod traffic is no longer restricted
+		return r.removeOwnedResource(ctx, hawtio, resources.NewDefaultNetworkPolicy(hawtio))
+	}
+
+	targetPolicy := resources.NewDefaultNetworkPolicy(hawtio)
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetPolicy, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := targetPolicy.DeepCopy()
+
+		// Set the owner reference for garbage collection
+		if err := controllerutil.SetControllerReference(hawtio, targetPolicy, r.scheme); err != nil {
+			return err
+		}
+
+		reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcileNetworkPolicy", hawtio.Name))
+		blueprint := resources.NewNetworkPolicy(hawtio, r.apiSpec, reqLogger)
+
+		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *networkingv1.NetworkPolicy) {
+			// If hydration stripped required fields, patch them directly back from source
+			if len(hydrated.Spec.PolicyTypes) == 0 {
+				hydrated.Spec.PolicyTypes = source.Spec.PolicyTypes
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		targetPolicy.Labels = util.MergeMap(targetPolicy.Labels, blueprint.Labels)
+		targetPolicy.Annotations = util.MergeMap(targetPolicy.Annotations, blueprint.Annotations)
+		// Assign the fully hydrated and patched blueprint spec
+		targetPolicy.Spec = serverBlueprint.Spec
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("NetworkPolicy", liveSnapshot, targetPolicy)
+
+		return nil
+	})
+	if err != nil {
+		return opResult, err
+	}
+
+	util.ReportResourceChange("NetworkPolicy", targetPolicy, opResult)
+	return opResult, nil
+}
+
 func (r *ReconcileHawtio) reconcileRoute(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) (*routev1.Route, controllerutil.OperationResult, error) {
-	// Only create a route if confirmed as Openshift and supports routes
-	if !r.apiSpec.IsOpenShift4 || !r.apiSpec.Routes {
-		return nil, controllerutil.OperationResultNone, nil
+	// Only create a route if confirmed as Openshift, supports routes and is selected
+	if !oresources.IsRouteApplicable(hawtio, r.apiSpec) {
+		if !r.apiSpec.Routes {
+			// The cluster does not support routes so there cannot be any route
+			return nil, controllerutil.OperationResultNone, nil
+		}
+		// Deleted once another exposure is selected
+		opResult, err := r.removeOwnedResource(ctx, hawtio, oresources.NewDefaultRoute(hawtio))
+		return nil, opResult, err
 	}
 
 	existingRoute := &routev1.Route{}
@@ -120,14 +190,41 @@ func (r *ReconcileHawtio) reconcileRoute(ctx context.Context, hawtio *hawtiov2.H
 			r.logger.Info("Deleting Route to trigger hostname regeneration.", "Route.Name", existingRoute.Name)
 			if err := r.client.Delete(ctx, existingRoute); err != nil {
 				r.logger.Error(err, "Failed to delete Route for regeneration")
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonRouteDeletionFailed,
+					"Failed to delete route %s to regenerate its host: %v", existingRoute.Name, err)
 				return nil, controllerutil.OperationResultNone, err
 			}
 
+			r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonRouteRegenerating,
+				"Deleted route %s with host %s so that its host is regenerated", existingRoute.Name, existingRoute.Spec.Host)
+
 			// Deletion was successful. We must stop this reconciliation loop here.
 			// The next loop will find the Route is missing and will create a new one.
 			// Returning (nil, nil) signals success for this loop, allowing the next one to proceed cleanly.
 			return nil, controllerutil.OperationResultUpdated, nil
 		}
+
+		existingPolicy := existingRoute.Spec.WildcardPolicy
+		if existingPolicy == "" {
+			existingPolicy = routev1.WildcardPolicyNone
+		}
+		if wildcardPolicy := oresources.GetRouteWildcardPolicy(hawtio); existingPolicy != wildcardPolicy {
+			// The wildcard policy of a route is immutable.
+			// We must delete the route to change it.
+
+			r.logger.Info("Deleting Route to change its wildcard policy.", "Route.Name", existingRoute.Name)
+			if err := r.client.Delete(ctx, existingRoute); err != nil {
+				r.logger.Error(err, "Failed to delete Route for regeneration")
+				r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonRouteDeletionFailed,
+					"Failed to delete route %s to change its wildcard policy: %v", existingRoute.Name, err)
+				return nil, controllerutil.OperationResultNone, err
+			}
+
+			r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonRouteRegenerating,
+				"Deleted route %s so that it is recreated with the %s wildcard policy", existingRoute.Name, wildcardPolicy)
+
+			return nil, controllerutil.OperationResultUpdated, nil
+		}
 	} else if !kerrors.IsNotFound(err) {
 		// A real error occurred trying to get the Route. Fail fast.
 		r.logger.Error(err, "Failed to get existing Route for pre-check")
@@ -180,8 +277,8 @@ func (r *ReconcileHawtio) reconcileRoute(ctx context.Context, hawtio *hawtiov2.H
 			return err
 		}
 
-		targetRoute.Labels = util.MergeMap(targetRoute.Labels, blueprint.Labels)
-		targetRoute.Annotations = util.MergeMap(targetRoute.Annotations, serverBlueprint.Annotations)
+		// The router shard labels and the router annotations may have been removed
+		mergeManagedMetadata(targetRoute, blueprint.Labels, serverBlueprint.Annotations, oresources.RouteIPWhitelistAnnotation)
 		// Assign the fully hydrated and patched blueprint spec
 		targetRoute.Spec = serverBlueprint.Spec
 
@@ -195,7 +292,7 @@ func (r *ReconcileHawtio) reconcileRoute(ctx context.Context, hawtio *hawtiov2.H
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, oresources.NewDefaultRoute(hawtio))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, oresources.NewDefaultRoute(hawtio))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -211,12 +308,103 @@ func (r *ReconcileHawtio) reconcileRoute(ctx context.Context, hawtio *hawtiov2.H
 	return targetRoute, opResult, nil
 }
 
-func (r *ReconcileHawtio) reconcileIngress(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) (*networkingv1.Ingress, controllerutil.OperationResult, error) {
-	// Only create an ingress if confirmed as not a route version of Openshift
-	if r.apiSpec.IsOpenShift4 && r.apiSpec.Routes {
+// reconcileAliasRoutes reconciles a route for each of the additional host names
+// of the console, and deletes the routes of the host names that were removed
+func (r *ReconcileHawtio) reconcileAliasRoutes(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) ([]*routev1.Route, controllerutil.OperationResult, error) {
+	if !r.apiSpec.Routes {
+		// The cluster does not support routes so there cannot be any route
 		return nil, controllerutil.OperationResultNone, nil
 	}
 
+	var hosts []string
+	if oresources.IsRouteApplicable(hawtio, r.apiSpec) {
+		hosts = hawtio.Spec.Hosts
+	}
+
+	result := controllerutil.OperationResultNone
+	var aliasRoutes []*routev1.Route
+	for _, host := range hosts {
+		targetRoute := oresources.NewDefaultAliasRoute(hawtio, host)
+
+		opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetRoute, func() error {
+			// A read-only copy of the cluster state for diff logging
+			liveSnapshot := targetRoute.DeepCopy()
+
+			// Set the owner reference for garbage collection.
+			if err := controllerutil.SetControllerReference(hawtio, targetRoute, r.scheme); err != nil {
+				return err
+			}
+
+			reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcileAliasRoutes", hawtio.Name))
+			blueprint := oresources.NewAliasRoute(hawtio, host, deploymentConfig.tlsRouteSecret, deploymentConfig.caCertRouteSecret, reqLogger)
+
+			serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *routev1.Route) {
+				// If hydration stripped required fields, patch them directly back from source
+				if hydrated.Spec.To.Kind == "" || hydrated.Spec.To.Name == "" {
+					hydrated.Spec.To = source.Spec.To
+				}
+				if hydrated.Spec.TLS == nil && source.Spec.TLS != nil {
+					hydrated.Spec.TLS = source.Spec.TLS
+				}
+				hydrated.Spec.Host = source.Spec.Host
+			})
+			if err != nil {
+				return err
+			}
+
+			// The router shard labels and the router annotations may have been removed
+			mergeManagedMetadata(targetRoute, blueprint.Labels, serverBlueprint.Annotations, oresources.RouteIPWhitelistAnnotation)
+			// Assign the fully hydrated and patched blueprint spec
+			targetRoute.Spec = serverBlueprint.Spec
+
+			// Report any known differences to the log (only if in debug log level)
+			util.ReportDiff("Route", liveSnapshot, targetRoute)
+
+			return nil
+		})
+		if err != nil {
+			return nil, result, err
+		}
+
+		util.ReportResourceChange("Route", targetRoute, opResult)
+		if opResult != controllerutil.OperationResultNone {
+			result = controllerutil.OperationResultUpdated
+		}
+		aliasRoutes = append(aliasRoutes, targetRoute)
+	}
+
+	// Delete the routes of the host names that were removed
+	routes := &routev1.RouteList{}
+	if err := r.client.List(ctx, routes, client.InNamespace(hawtio.Namespace), client.MatchingLabels(resources.LabelsForHawtio(hawtio.Name))); err != nil {
+		return nil, result, err
+	}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !strings.HasPrefix(route.Name, hawtio.Name+"-alias-") || slices.ContainsFunc(aliasRoutes, func(aliasRoute *routev1.Route) bool {
+			return aliasRoute.Name == route.Name
+		}) {
+			continue
+		}
+		opResult, err := r.removeOwnedResource(ctx, hawtio, route)
+		if err != nil {
+			return nil, result, err
+		}
+		if opResult != controllerutil.OperationResultNone {
+			result = controllerutil.OperationResultUpdated
+		}
+	}
+
+	return aliasRoutes, result, nil
+}
+
+func (r *ReconcileHawtio) reconcileIngress(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) (*networkingv1.Ingress, controllerutil.OperationResult, error) {
+	// Only create an ingress if selected, explicitly or by default when neither
+	// a route nor an HTTPRoute applies to the cluster
+	if !kresources.IsIngressApplicable(hawtio, r.apiSpec) {
+		opResult, err := r.removeOwnedResource(ctx, hawtio, kresources.NewDefaultIngress(hawtio))
+		return nil, opResult, err
+	}
+
 	targetIngress := kresources.NewDefaultIngress(hawtio)
 
 	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetIngress, func() error {
@@ -245,8 +433,9 @@ func (r *ReconcileHawtio) reconcileIngress(ctx context.Context, hawtio *hawtiov2
 			return err
 		}
 
-		targetIngress.Labels = util.MergeMap(targetIngress.Labels, blueprint.Labels)
-		targetIngress.Annotations = util.MergeMap(targetIngress.Annotations, serverBlueprint.Annotations)
+		// The annotations may have been removed, or the NGINX ones no longer
+		// apply to the ingress class
+		mergeManagedMetadata(targetIngress, blueprint.Labels, serverBlueprint.Annotations, kresources.NginxAnnotations...)
 		// Assign the fully hydrated and patched blueprint spec
 		targetIngress.Spec = serverBlueprint.Spec
 
@@ -260,7 +449,7 @@ func (r *ReconcileHawtio) reconcileIngress(ctx context.Context, hawtio *hawtiov2
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, kresources.NewDefaultIngress(hawtio))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, kresources.NewDefaultIngress(hawtio))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -275,3 +464,246 @@ func (r *ReconcileHawtio) reconcileIngress(ctx context.Context, hawtio *hawtiov2
 	util.ReportResourceChange("Ingress", targetIngress, opResult)
 	return targetIngress, opResult, nil
 }
+
+func (r *ReconcileHawtio) reconcileHTTPRoute(ctx context.Context, hawtio *hawtiov2.Hawtio) (*gatewayv1.HTTPRoute, controllerutil.OperationResult, error) {
+	if !kresources.IsHTTPRouteApplicable(hawtio, r.apiSpec) {
+		if !r.apiSpec.GatewayAPI {
+			// The cluster does not support the Gateway API so there cannot be any HTTPRoute
+			return nil, controllerutil.OperationResultNone, nil
+		}
+		// Deleted, eg. once the parent Gateway is no longer referenced or another exposure is selected
+		opResult, err := r.removeOwnedResource(ctx, hawtio, kresources.NewDefaultHTTPRoute(hawtio))
+		return nil, opResult, err
+	}
+
+	targetHTTPRoute := kresources.NewDefaultHTTPRoute(hawtio)
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetHTTPRoute, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := targetHTTPRoute.DeepCopy()
+
+		// Set the owner reference for garbage collection.
+		if err := controllerutil.SetControllerReference(hawtio, targetHTTPRoute, r.scheme); err != nil {
+			return err
+		}
+
+		reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcileHTTPRoute", hawtio.Name))
+		blueprint := kresources.NewHTTPRoute(hawtio, r.apiSpec, reqLogger)
+
+		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *gatewayv1.HTTPRoute) {
+			// If hydration stripped required fields, patch them directly back from source
+			if len(hydrated.Spec.ParentRefs) == 0 {
+				hydrated.Spec.ParentRefs = source.Spec.ParentRefs
+			}
+			if len(hydrated.Spec.Rules) == 0 {
+				hydrated.Spec.Rules = source.Spec.Rules
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		targetHTTPRoute.Labels = util.MergeMap(targetHTTPRoute.Labels, blueprint.Labels)
+		targetHTTPRoute.Annotations = util.MergeMap(targetHTTPRoute.Annotations, serverBlueprint.Annotations)
+		// Assign the fully hydrated and patched blueprint spec
+		targetHTTPRoute.Spec = serverBlueprint.Spec
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("HTTPRoute", liveSnapshot, targetHTTPRoute)
+
+		return nil
+	})
+	if err != nil {
+		return nil, opResult, err
+	}
+
+	util.ReportResourceChange("HTTPRoute", targetHTTPRoute, opResult)
+	return targetHTTPRoute, opResult, nil
+}
+
+// getParentGateway returns the Gateway the HTTPRoute is attached to, or nil if it cannot be read,
+// in which case the console URL is derived from the HTTPRoute only
+func (r *ReconcileHawtio) getParentGateway(ctx context.Context, hawtio *hawtiov2.Hawtio) *gatewayv1.Gateway {
+	parentRef := hawtio.Spec.HTTPRoute.ParentRef
+	namespace := parentRef.Namespace
+	if namespace == "" {
+		namespace = hawtio.Namespace
+	}
+
+	// The Gateway is not owned by the operator so it is not cached
+	gateway := &gatewayv1.Gateway{}
+	if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: parentRef.Name}, gateway); err != nil {
+		r.logger.V(util.DebugLogLevel).Info(fmt.Sprintf("Unable to read the parent Gateway %s/%s: %v", namespace, parentRef.Name, err))
+		return nil
+	}
+
+	return gateway
+}
+
+func (r *ReconcileHawtio) reconcileBackendTLSPolicy(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) (controllerutil.OperationResult, error) {
+	if !kresources.IsBackendTLSPolicyApplicable(hawtio, r.apiSpec) {
+		if !r.apiSpec.BackendTLSPolicy {
+			if kresources.IsHTTPRouteApplicable(hawtio, r.apiSpec) && util.IsSSL(hawtio, r.apiSpec) {
+				r.logger.Info("The cluster does not support the BackendTLSPolicy API. The Gateway must be configured to re-encrypt the traffic to the Hawtio service.")
+			}
+			return controllerutil.OperationResultNone, nil
+		}
+		// Deleted, eg. once the parent Gateway is no longer referenced
+		opResult, err := r.removeOwnedResource(ctx, hawtio, kresources.NewDefaultBackendTLSPolicy(hawtio))
+		if err != nil {
+			return opResult, err
+		}
+		caResult, err := r.removeOwnedResource(ctx, hawtio, kresources.NewDefaultBackendCAConfigMap(hawtio))
+		if caResult != controllerutil.OperationResultNone {
+			opResult = caResult
+		}
+		return opResult, err
+	}
+
+	caBundle, hostname, err := r.resolveBackendCA(ctx, hawtio, deploymentConfig)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	reqLogger := hawtioLogger.WithName(fmt.Sprintf("%s-reconcileBackendTLSPolicy", hawtio.Name))
+
+	configMap := kresources.NewDefaultBackendCAConfigMap(hawtio)
+
+	caResult, err := controllerutil.CreateOrUpdate(ctx, r.client, configMap, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := configMap.DeepCopy()
+
+		// Set the owner reference for garbage collection.
+		if err := controllerutil.SetControllerReference(hawtio, configMap, r.scheme); err != nil {
+			return err
+		}
+
+		blueprint := kresources.NewBackendCAConfigMap(hawtio, caBundle, reqLogger)
+
+		configMap.Labels = util.MergeMap(configMap.Labels, blueprint.Labels)
+		configMap.Annotations = util.MergeMap(configMap.Annotations, blueprint.Annotations)
+		// No hydration needed because K8s doesn't default this field.
+		configMap.Data = blueprint.Data
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("ConfigMap", liveSnapshot, configMap)
+
+		return nil
+	})
+	if err != nil {
+		return caResult, err
+	}
+
+	util.ReportResourceChange("ConfigMap", configMap, caResult)
+
+	targetPolicy := kresources.NewDefaultBackendTLSPolicy(hawtio)
+
+	opResult, err := controllerutil.CreateOrUpdate(ctx, r.client, targetPolicy, func() error {
+		// A read-only copy of the cluster state for diff logging
+		liveSnapshot := targetPolicy.DeepCopy()
+
+		// Set the owner reference for garbage collection.
+		if err := controllerutil.SetControllerReference(hawtio, targetPolicy, r.scheme); err != nil {
+			return err
+		}
+
+		blueprint := kresources.NewBackendTLSPolicy(hawtio, hostname, reqLogger)
+
+		serverBlueprint, err := hydrateDefaults(ctx, r.client, blueprint, func(source, hydrated *gatewayv1.BackendTLSPolicy) {
+			// If hydration stripped required fields, patch them directly back from source
+			if len(hydrated.Spec.TargetRefs) == 0 {
+				hydrated.Spec.TargetRefs = source.Spec.TargetRefs
+			}
+			if len(hydrated.Spec.Validation.CACertificateRefs) == 0 {
+				hydrated.Spec.Validation = source.Spec.Validation
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		targetPolicy.Labels = util.MergeMap(targetPolicy.Labels, blueprint.Labels)
+		targetPolicy.Annotations = util.MergeMap(targetPolicy.Annotations, serverBlueprint.Annotations)
+		// Assign the fully hydrated and patched blueprint spec
+		targetPolicy.Spec = serverBlueprint.Spec
+
+		// Report any known differences to the log (only if in debug log level)
+		util.ReportDiff("BackendTLSPolicy", liveSnapshot, targetPolicy)
+
+		return nil
+	})
+	if err != nil {
+		return opResult, err
+	}
+
+	util.ReportResourceChange("BackendTLSPolicy", targetPolicy, opResult)
+	if opResult == controllerutil.OperationResultNone {
+		opResult = caResult
+	}
+	return opResult, nil
+}
+
+// resolveBackendCA returns the CA bundle, and the host name, the serving certificate
+// of the Hawtio service is validated against by the Gateway
+func (r *ReconcileHawtio) resolveBackendCA(ctx context.Context, hawtio *hawtiov2.Hawtio, deploymentConfig DeploymentConfiguration) ([]byte, string, error) {
+	// The DNS name of the service, that the serving certificate is valid for
+	hostname := fmt.Sprintf("%s.%s.svc", hawtio.Name, hawtio.Namespace)
+
+	if r.apiSpec.IsOpenShift4 {
+		// The serving certificate is issued by the service CA, whose bundle
+		// is published by OpenShift in every namespace
+		serviceCA := &corev1.ConfigMap{}
+		err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: hawtio.Namespace, Name: openShiftServiceCAConfigMap}, serviceCA)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read the service CA bundle: %w", err)
+		}
+		caBundle := serviceCA.Data[openShiftServiceCAKey]
+		if caBundle == "" {
+			return nil, "", fmt.Errorf("the ConfigMap %s does not contain the key %s", openShiftServiceCAConfigMap, openShiftServiceCAKey)
+		}
+		return []byte(caBundle), hostname, nil
+	}
+
+	// The serving certificate is self-signed, so it is its own CA
+	servingSecret := deploymentConfig.servingCertSecret
+	if servingSecret == nil || len(servingSecret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, "", fmt.Errorf("the serving certificate is not available")
+	}
+	return servingSecret.Data[corev1.TLSCertKey], hostname, nil
+}
+
+// reportAccessRestriction records whether the allowed source ranges are enforced on
+// all the means the console is exposed with. Neither the Gateway API, other ingress
+// classes than NGINX, nor the node ports of the service support restricting the
+// client addresses, so the ranges would otherwise be silently ignored.
+func (r *ReconcileHawtio) reportAccessRestriction(hawtio *hawtiov2.Hawtio) {
+	if len(hawtio.Spec.Access.AllowedSourceRanges) == 0 {
+		removeCondition(&hawtio.Status, hawtiov2.HawtioConditionAccessRestricted)
+		return
+	}
+
+	var ignoredBy []string
+	switch util.GetExposureType(hawtio, r.apiSpec) {
+	case hawtiov2.GatewayAPIHawtioExposureType:
+		ignoredBy = append(ignoredBy, "the HTTPRoute")
+	case hawtiov2.IngressHawtioExposureType:
+		if !kresources.IsNginxIngress(hawtio) {
+			ignoredBy = append(ignoredBy, fmt.Sprintf("the Ingress of the %s class", hawtio.Spec.Ingress.ClassName))
+		}
+	}
+	if hawtio.Spec.Service.Type == corev1.ServiceTypeNodePort {
+		ignoredBy = append(ignoredBy, "the node ports of the Service")
+	}
+
+	if len(ignoredBy) > 0 {
+		message := fmt.Sprintf("The allowed source ranges are not enforced by %s", strings.Join(ignoredBy, ", nor "))
+		r.logger.Info(message)
+		if turnsFalse(&hawtio.Status, hawtiov2.HawtioConditionAccessRestricted) {
+			r.recorder.Event(hawtio, corev1.EventTypeWarning, EventReasonSourceRangesIgnored, message)
+		}
+		setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionAccessRestricted, metav1.ConditionFalse, reasonSourceRangesNotEnforced, message)
+		return
+	}
+
+	setCondition(hawtio, &hawtio.Status, hawtiov2.HawtioConditionAccessRestricted, metav1.ConditionTrue, reasonSourceRangesEnforced, "The allowed source ranges are enforced")
+}
@@ -3,6 +3,8 @@ package hawtio
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -11,7 +13,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	consolev1 "github.com/openshift/api/console/v1"
-	routev1 "github.com/openshift/api/route/v1"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 
@@ -41,7 +42,23 @@ func (r *ReconcileHawtio) removeConsoleLink(ctx context.Context, consoleLinkName
 	return controllerutil.OperationResultUpdated, nil
 }
 
-func (r *ReconcileHawtio) reconcileConsoleLink(ctx context.Context, hawtio *hawtiov2.Hawtio, namespacedName client.ObjectKey, deploymentConfig DeploymentConfiguration, route *routev1.Route) (controllerutil.OperationResult, error) {
+// isConsoleLinkApplicable returns whether a ConsoleLink should exist for the Hawtio CR.
+// The only prerequisites are being on OCP and having the console exposed with a known host.
+func (r *ReconcileHawtio) isConsoleLinkApplicable(hawtio *hawtiov2.Hawtio, consoleURL string) bool {
+	if !r.apiSpec.IsOpenShift4 {
+		return false
+	}
+
+	validURL := false
+	if u, err := url.Parse(consoleURL); err == nil {
+		validURL = u.Host != "" && !strings.HasPrefix(u.Host, "*")
+	}
+	isClusterType := hawtio.Spec.Type == hawtiov2.ClusterHawtioDeploymentType
+	isNamespaceTypeWithDashboard := hawtio.Spec.Type == hawtiov2.NamespaceHawtioDeploymentType && r.apiSpec.IsOpenShift43Plus
+	return validURL && (isClusterType || isNamespaceTypeWithDashboard)
+}
+
+func (r *ReconcileHawtio) reconcileConsoleLink(ctx context.Context, hawtio *hawtiov2.Hawtio, namespacedName client.ObjectKey, deploymentConfig DeploymentConfiguration, consoleURL string) (controllerutil.OperationResult, error) {
 	// If not OpenShift 4, ConsoleLink is irrelevant. Do nothing.
 	if !r.apiSpec.IsOpenShift4 {
 		r.logger.V(util.DebugLogLevel).Info("Not an OpenShift 4 cluster, skipping ConsoleLink reconciliation.")
@@ -50,16 +67,10 @@ func (r *ReconcileHawtio) reconcileConsoleLink(ctx context.Context, hawtio *hawt
 
 	consoleLinkName := namespacedName.Name + "-" + namespacedName.Namespace
 
-	// The only prerequisites are being on OCP and having a valid Route.
-	validRoute := r.apiSpec.Routes && route != nil && route.Spec.Host != ""
-	isClusterType := hawtio.Spec.Type == hawtiov2.ClusterHawtioDeploymentType
-	isNamespaceTypeWithDashboard := hawtio.Spec.Type == hawtiov2.NamespaceHawtioDeploymentType && r.apiSpec.IsOpenShift43Plus
-	shouldExist := validRoute && (isClusterType || isNamespaceTypeWithDashboard)
-
 	// Prerequisite check
 	r.logger.V(util.DebugLogLevel).Info("Reconcile ConsoleLink - Prerequisite Check")
-	if !shouldExist {
-		r.logger.V(util.DebugLogLevel).Info("Removing ConsoleLink as not applicable", "valid route", validRoute, "cluster link?", isClusterType, "namespace link?", isNamespaceTypeWithDashboard)
+	if !r.isConsoleLinkApplicable(hawtio, consoleURL) {
+		r.logger.V(util.DebugLogLevel).Info("Removing ConsoleLink as not applicable", "url", consoleURL, "type", hawtio.Spec.Type)
 		return r.removeConsoleLink(ctx, consoleLinkName)
 	}
 
@@ -81,10 +92,10 @@ func (r *ReconcileHawtio) reconcileConsoleLink(ctx context.Context, hawtio *hawt
 
 		if hawtio.Spec.Type == hawtiov2.ClusterHawtioDeploymentType {
 			r.logger.V(util.DebugLogLevel).Info("Adding console link as Application Menu Link")
-			blueprint = openshift.NewApplicationMenuLink(consoleLinkName, namespacedName.Namespace, route, hawtconfig)
+			blueprint = openshift.NewApplicationMenuLink(consoleLinkName, namespacedName.Namespace, consoleURL, hawtconfig)
 		} else if r.apiSpec.IsOpenShift43Plus {
 			r.logger.V(util.DebugLogLevel).Info("Adding console link as Namespace Dashboard Link")
-			blueprint = openshift.NewNamespaceDashboardLink(consoleLinkName, namespacedName.Namespace, route, hawtconfig)
+			blueprint = openshift.NewNamespaceDashboardLink(consoleLinkName, namespacedName.Namespace, consoleURL, hawtconfig)
 		} else {
 			// If no link should exist, we can't model that with CreateOrUpdate.
 			return fmt.Errorf("Unsupported ConsoleLink configuration - neither Application nor Namespace link")
@@ -125,7 +136,7 @@ func (r *ReconcileHawtio) reconcileConsoleLink(ctx context.Context, hawtio *hawt
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, openshift.NewDefaultConsoleLink(consoleLinkName))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, openshift.NewDefaultConsoleLink(consoleLinkName))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
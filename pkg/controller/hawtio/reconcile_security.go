@@ -3,11 +3,14 @@ package hawtio
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
 	oauthv1 "github.com/openshift/api/oauth/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,6 +49,19 @@ func (r *ReconcileHawtio) verifyRBACConfigMap(ctx context.Context, hawtio *hawti
 	return true, nil
 }
 
+// reportInvalidRBACConfigMap records the RBAC ConfigMap as invalid in the
+// status conditions while the reconciler polls for it to be corrected.
+func (r *ReconcileHawtio) reportInvalidRBACConfigMap(ctx context.Context, hawtio *hawtiov2.Hawtio, reason, message string) {
+	err := r.updateStatus(ctx, hawtio, func(status *hawtiov2.HawtioStatus) {
+		status.ObservedGeneration = hawtio.Generation
+		setCondition(hawtio, status, hawtiov2.HawtioConditionRBACConfigValid, metav1.ConditionFalse, reason, message)
+		setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reason, message)
+	})
+	if err != nil {
+		r.logger.Error(err, "Failed to record invalid RBAC ConfigMap in Hawtio status")
+	}
+}
+
 func (r *ReconcileHawtio) reconcileServiceAccount(ctx context.Context, hawtio *hawtiov2.Hawtio) (controllerutil.OperationResult, error) {
 	serviceAccount := resources.NewDefaultServiceAccount(hawtio)
 
@@ -77,7 +93,7 @@ func (r *ReconcileHawtio) reconcileServiceAccount(ctx context.Context, hawtio *h
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, resources.NewDefaultServiceAccount(hawtio))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, resources.NewDefaultServiceAccount(hawtio))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -93,7 +109,51 @@ func (r *ReconcileHawtio) reconcileServiceAccount(ctx context.Context, hawtio *h
 	return opResult, nil
 }
 
-func (r *ReconcileHawtio) reconcileOAuthClient(ctx context.Context, hawtio *hawtiov2.Hawtio, newRouteURL string, namespacedName client.ObjectKey) (controllerutil.OperationResult, error) {
+// reconcileOAuthRedirects updates the OAuth redirect annotations of the service account,
+// once the URLs the console has been exposed at in this pass are known. Only the
+// annotations are patched, so that the service account is reconciled ahead of the
+// deployment regardless of the exposure. The redirect annotations are owned by the
+// operator, so they are replaced rather than merged, to remove those of the hosts
+// no longer exposed.
+func (r *ReconcileHawtio) reconcileOAuthRedirects(ctx context.Context, hawtio *hawtiov2.Hawtio, urls []string) (controllerutil.OperationResult, error) {
+	serviceAccount := resources.NewDefaultServiceAccount(hawtio)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(serviceAccount), serviceAccount); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	redirects, err := resources.NewOAuthRedirectAnnotations(hawtio, r.apiSpec, urls)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	previous := serviceAccount.DeepCopy()
+	maps.DeleteFunc(serviceAccount.Annotations, func(key, _ string) bool {
+		return resources.IsOAuthRedirectAnnotation(key)
+	})
+	serviceAccount.Annotations = util.MergeMap(serviceAccount.Annotations, redirects)
+
+	if maps.Equal(previous.Annotations, serviceAccount.Annotations) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	// Report any known differences to the log (only if in debug log level)
+	util.ReportDiff("ServiceAccount", previous, serviceAccount)
+
+	if err := r.client.Patch(ctx, serviceAccount, client.MergeFrom(previous)); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	util.ReportResourceChange("ServiceAccount", serviceAccount, controllerutil.OperationResultUpdated)
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// isOAuthClientApplicable returns whether the Hawtio CR requires an OAuthClient,
+// ie. it is deployed in cluster mode on OpenShift 4
+func (r *ReconcileHawtio) isOAuthClientApplicable(hawtio *hawtiov2.Hawtio) bool {
+	return r.apiSpec.IsOpenShift4 && hawtio.Spec.Type == hawtiov2.ClusterHawtioDeploymentType
+}
+
+func (r *ReconcileHawtio) reconcileOAuthClient(ctx context.Context, hawtio *hawtiov2.Hawtio, newRouteURLs []string, namespacedName client.ObjectKey) (controllerutil.OperationResult, error) {
 	if !r.apiSpec.IsOpenShift4 {
 		// Not applicable to cluster
 		return controllerutil.OperationResultNone, nil
@@ -120,9 +180,15 @@ func (r *ReconcileHawtio) reconcileOAuthClient(ctx context.Context, hawtio *hawt
 			return controllerutil.OperationResultNone, err
 		}
 
-		// Found an existing OAuthClient, let's remove our URI from it.
-		r.logger.Info(fmt.Sprintf("Hawtio is not cluster-scoped, removing RedirectURI from OAuthClient %v", namespacedName))
-		if resources.RemoveRedirectURIFromOauthClient(existingOAuthClient, newRouteURL) {
+		// Found an existing OAuthClient, let's remove our URIs from it.
+		r.logger.Info(fmt.Sprintf("Hawtio is not cluster-scoped, removing RedirectURIs from OAuthClient %v", namespacedName))
+		updated := false
+		for _, newRouteURL := range newRouteURLs {
+			if resources.RemoveRedirectURIFromOauthClient(existingOAuthClient, newRouteURL) {
+				updated = true
+			}
+		}
+		if updated {
 			err := r.client.Update(ctx, existingOAuthClient)
 			return controllerutil.OperationResultUpdated, err
 		}
@@ -173,7 +239,7 @@ func (r *ReconcileHawtio) reconcileOAuthClient(ctx context.Context, hawtio *hawt
 	// since it is legacy and not labelled. The create failed so it
 	// should be adopted and reconciled.
 	if err != nil && kerrors.IsAlreadyExists(err) {
-		adoptionErr := r.adoptLegacyResource(ctx, resources.NewDefaultOAuthClient(clientName))
+		adoptionErr := r.adoptLegacyResource(ctx, hawtio, resources.NewDefaultOAuthClient(clientName))
 		if adoptionErr != nil {
 			// If adoption failed (e.g., API error) or an adoption was called
 			// return any of these errors
@@ -194,24 +260,28 @@ func (r *ReconcileHawtio) reconcileOAuthClient(ctx context.Context, hawtio *hawt
 	}
 
 	updateOAuthClient := false
-	oldRouteURL := hawtio.Status.URL
-	// Remove the old URL if it's different from the new one
-	if oldRouteURL != "" && oldRouteURL != newRouteURL {
+	// Remove the old URLs that are no longer among the new ones
+	for _, oldRouteURL := range util.GetStatusURLs(hawtio) {
+		if slices.Contains(newRouteURLs, oldRouteURL) {
+			continue
+		}
 		r.logger.Info("Removing stale RedirectURI from OAuthClient", "URI", oldRouteURL)
 		if resources.RemoveRedirectURIFromOauthClient(targetOAuthClient, oldRouteURL) {
 			updateOAuthClient = true
 		}
 	}
 
-	// Add the current route URL if it's not already present.
-	if ok, _ := resources.OauthClientContainsRedirectURI(targetOAuthClient, newRouteURL); !ok && newRouteURL != "" {
-		r.logger.V(util.DebugLogLevel).Info("OAuthClient URI mismatch detected",
-			"Wanted", newRouteURL,
-			"ExistingURIs", targetOAuthClient.RedirectURIs)
+	// Add the current route URLs if they are not already present.
+	for _, newRouteURL := range newRouteURLs {
+		if ok, _ := resources.OauthClientContainsRedirectURI(targetOAuthClient, newRouteURL); !ok && newRouteURL != "" {
+			r.logger.V(util.DebugLogLevel).Info("OAuthClient URI mismatch detected",
+				"Wanted", newRouteURL,
+				"ExistingURIs", targetOAuthClient.RedirectURIs)
 
-		r.logger.Info("Adding new RedirectURI to OAuthClient", "URI", newRouteURL)
-		targetOAuthClient.RedirectURIs = append(targetOAuthClient.RedirectURIs, newRouteURL)
-		updateOAuthClient = true
+			r.logger.Info("Adding new RedirectURI to OAuthClient", "URI", newRouteURL)
+			targetOAuthClient.RedirectURIs = append(targetOAuthClient.RedirectURIs, newRouteURL)
+			updateOAuthClient = true
+		}
 	}
 
 	if updateOAuthClient {
@@ -2,39 +2,179 @@ package hawtio
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+)
 
-	"github.com/hawtio/hawtio-operator/pkg/util"
+// The reasons reported alongside the Hawtio status conditions
+const (
+	reasonAsExpected               = "AsExpected"
+	reasonDeployed                 = "Deployed"
+	reasonInitializing             = "Initializing"
+	reasonRollingOut               = "RollingOut"
+	reasonRolloutComplete          = "RolloutComplete"
+	reasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	reasonDeploymentNotReady       = "DeploymentNotReady"
+	reasonReconcileFailed          = "ReconcileFailed"
+	reasonUnsupportedType          = "UnsupportedType"
+	reasonDefaultRBAC              = "DefaultRBAC"
+	reasonConfigMapValid           = "ConfigMapValid"
+	reasonConfigMapNotFound        = "ConfigMapNotFound"
+	reasonConfigMapInvalid         = "ConfigMapInvalid"
+	reasonDefaultVersion           = "DefaultVersion"
+	reasonVersionSupported         = "VersionSupported"
+	reasonUnsupportedVersion       = "UnsupportedVersion"
+	reasonCertificatesValid        = "CertificatesValid"
+	reasonCertificateError         = "CertificateError"
+	reasonAdmitted                 = "Admitted"
+	reasonAdmissionPending         = "AdmissionPending"
+	reasonAddressAssigned          = "AddressAssigned"
+	reasonAwaitingAddress          = "AwaitingAddress"
+	reasonAccepted                 = "Accepted"
+	reasonAcceptancePending        = "AcceptancePending"
+	reasonGatewayAPIUnavailable    = "GatewayAPIUnavailable"
+	reasonRoutesUnavailable        = "RoutesUnavailable"
+	reasonReconciled               = "Reconciled"
+	reasonSourceRangesEnforced     = "SourceRangesEnforced"
+	reasonSourceRangesNotEnforced  = "SourceRangesNotEnforced"
+	reasonPodTemplateApplied       = "PodTemplateApplied"
+	reasonPodTemplateConflicts     = "PodTemplateConflicts"
 )
 
-func (r *ReconcileHawtio) setHawtioPhase(ctx context.Context, hawtio *hawtiov2.Hawtio, phase hawtiov2.HawtioPhase) error {
-	r.logger.V(util.DebugLogLevel).Info("Setting Hawtio CR Phase:", "Phase", phase)
+// updateStatus applies the mutation to the Hawtio status and patches
+// the status sub-resource, only if anything has actually changed.
+func (r *ReconcileHawtio) updateStatus(ctx context.Context, hawtio *hawtiov2.Hawtio, mutate func(status *hawtiov2.HawtioStatus)) error {
+	previous := hawtio.DeepCopy()
+	mutate(&hawtio.Status)
+
+	if equality.Semantic.DeepEqual(previous.Status, hawtio.Status) {
+		return nil
+	}
+
+	return r.client.Status().Patch(ctx, hawtio, client.MergeFrom(previous))
+}
+
+// setCondition records a condition on the status, stamped with the
+// generation of the Hawtio CR currently being reconciled.
+func setCondition(hawtio *hawtiov2.Hawtio, status *hawtiov2.HawtioStatus, conditionType string, conditionStatus metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: hawtio.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// turnsFalse reports whether the condition is not False yet, so that the
+// warning events accompanying it are only emitted when it transitions.
+func turnsFalse(status *hawtiov2.HawtioStatus, conditionType string) bool {
+	return !meta.IsStatusConditionFalse(status.Conditions, conditionType)
+}
+
+// removeCondition drops a condition that is not applicable to the
+// current cluster or configuration.
+func removeCondition(status *hawtiov2.HawtioStatus, conditionType string) {
+	meta.RemoveStatusCondition(&status.Conditions, conditionType)
+}
+
+// reconcileFailed records the failure of a reconcile step in the status
+// conditions before handing the error on to handleResultAndError. If the
+// failed step owns a condition, conditionType names it, otherwise it is
+// empty and only the Degraded and Ready conditions are updated.
+func (r *ReconcileHawtio) reconcileFailed(ctx context.Context, hawtio *hawtiov2.Hawtio, conditionType, reason string, err error) (reconcile.Result, error) {
+	var reqErr *RequeueError
+	if errors.As(err, &reqErr) || err == ErrLegacyResourceAdopted {
+		// Not a failure, the step simply requires another pass
+		return handleResultAndError(err)
+	}
 
-	if hawtio.Status.Phase != phase {
-		previous := hawtio.DeepCopy()
-		hawtio.Status.Phase = phase
-		err := r.client.Status().Patch(ctx, hawtio, client.MergeFrom(previous))
-		if err != nil {
-			return fmt.Errorf("failed to update hawtio phase to %s: %v", phase, err)
+	statusErr := r.updateStatus(ctx, hawtio, func(status *hawtiov2.HawtioStatus) {
+		if conditionType != "" {
+			setCondition(hawtio, status, conditionType, metav1.ConditionFalse, reason, err.Error())
 		}
+		setCondition(hawtio, status, hawtiov2.HawtioConditionDegraded, metav1.ConditionTrue, reason, err.Error())
+		setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reasonReconcileFailed, err.Error())
+		status.ObservedGeneration = hawtio.Generation
+	})
+	if statusErr != nil {
+		r.logger.Error(statusErr, "Failed to record reconcile failure in Hawtio status")
 	}
 
-	return nil
+	return handleResultAndError(err)
 }
 
-// isDeploymentFailed checks if the Deployment has exceeded its progress deadline.
-func (r *ReconcileHawtio) isDeploymentFailed(deployment *appsv1.Deployment) bool {
+// setDeploymentConditions derives the Ready, Progressing and Degraded
+// conditions from the state of the Hawtio deployment.
+func (r *ReconcileHawtio) setDeploymentConditions(hawtio *hawtiov2.Hawtio, status *hawtiov2.HawtioStatus, deployment *appsv1.Deployment) {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	rollingOut := deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas < desired ||
+		deployment.Status.ReadyReplicas < desired ||
+		deployment.Status.Replicas > deployment.Status.UpdatedReplicas
+
+	failed := r.isDeploymentFailed(hawtio, deployment)
+
+	switch {
+	case failed:
+		setCondition(hawtio, status, hawtiov2.HawtioConditionProgressing, metav1.ConditionFalse, reasonProgressDeadlineExceeded, "The deployment has exceeded its progress deadline")
+	case rollingOut:
+		setCondition(hawtio, status, hawtiov2.HawtioConditionProgressing, metav1.ConditionTrue, reasonRollingOut,
+			fmt.Sprintf("%d of %d replicas updated and ready", min(deployment.Status.UpdatedReplicas, deployment.Status.ReadyReplicas), desired))
+	default:
+		setCondition(hawtio, status, hawtiov2.HawtioConditionProgressing, metav1.ConditionFalse, reasonRolloutComplete, "The deployment has been rolled out")
+	}
+
+	if failed {
+		setCondition(hawtio, status, hawtiov2.HawtioConditionDegraded, metav1.ConditionTrue, reasonProgressDeadlineExceeded, "The deployment has exceeded its progress deadline")
+	} else {
+		setCondition(hawtio, status, hawtiov2.HawtioConditionDegraded, metav1.ConditionFalse, reasonAsExpected, "")
+	}
+
+	if status.Phase == hawtiov2.HawtioPhaseDeployed {
+		setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionTrue, reasonDeployed,
+			fmt.Sprintf("%d of %d replicas ready", deployment.Status.ReadyReplicas, desired))
+	} else if failed {
+		setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reasonProgressDeadlineExceeded, "The deployment has exceeded its progress deadline")
+	} else {
+		setCondition(hawtio, status, hawtiov2.HawtioConditionReady, metav1.ConditionFalse, reasonDeploymentNotReady, "Waiting for the console pods to become ready")
+	}
+}
+
+// isDeploymentFailed checks if the Deployment has exceeded its progress deadline,
+// either as reported by the deployment controller, or, if the deployment controller
+// has not yet caught up, eg. with a shortened deadline, as configured in the Hawtio CR.
+func (r *ReconcileHawtio) isDeploymentFailed(hawtio *hawtiov2.Hawtio, deployment *appsv1.Deployment) bool {
 	for _, cond := range deployment.Status.Conditions {
-		// Check for the specific Type and Reason that indicate failure
-		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == "ProgressDeadlineExceeded" {
+		if cond.Type != appsv1.DeploymentProgressing {
+			continue
+		}
+		// Check for the specific Reason that indicates failure
+		if cond.Reason == "ProgressDeadlineExceeded" {
 			return true
 		}
+		// The rollout is complete or paused
+		if cond.Status != corev1.ConditionTrue || cond.Reason == "NewReplicaSetAvailable" {
+			return false
+		}
+		return time.Since(cond.LastUpdateTime.Time) > resources.ProgressDeadline(hawtio)
 	}
 	return false
 }
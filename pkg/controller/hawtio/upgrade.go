@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 
 	"github.com/hawtio/hawtio-operator/pkg/resources"
 	"github.com/hawtio/hawtio-operator/pkg/util"
@@ -16,7 +20,8 @@ import (
 // If upgrading from older version or overwriting a manual install it's possible a
 // resource may not have a label so cannot be 'seen' by the client's cache. So, need
 // to adopt the resource by providing it with a label and requeuing.
-func (r *ReconcileHawtio) adoptLegacyResource(ctx context.Context, obj client.Object) error {
+// The adoption is recorded as an event against the owning Hawtio CR.
+func (r *ReconcileHawtio) adoptLegacyResource(ctx context.Context, hawtio *hawtiov2.Hawtio, obj client.Object) error {
 	r.logger.Info(fmt.Sprintf("Adopting legacy resource [Type: %T] [Name: %s/%s]", obj, obj.GetNamespace(), obj.GetName()))
 	key := client.ObjectKeyFromObject(obj)
 
@@ -46,12 +51,22 @@ func (r *ReconcileHawtio) adoptLegacyResource(ctx context.Context, obj client.Ob
 	labels[resources.LabelAppKey] = resources.LabelAppValue
 	obj.SetLabels(labels)
 
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil {
+		kind = gvk.Kind
+	}
+
 	// Update the object (triggers a new Reconcile event)
 	if err := r.client.Update(ctx, obj); err != nil {
+		r.recorder.Eventf(hawtio, corev1.EventTypeWarning, EventReasonAdoptionFailed,
+			"Failed to adopt legacy %s %s: %v", kind, obj.GetName(), err)
 		// Update failed so return that error
 		return err
 	}
 
+	r.recorder.Eventf(hawtio, corev1.EventTypeNormal, EventReasonLegacyResourceAdopted,
+		"Adopted legacy %s %s by adding the missing %s label", kind, obj.GetName(), resources.LabelAppKey)
+
 	// Return adopted error to signal that reconcile should be
 	// requeued immediately and object should be found in the cache
 	return ErrLegacyResourceAdopted
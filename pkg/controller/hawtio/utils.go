@@ -1,7 +1,19 @@
 package hawtio
 
 import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/util"
 )
 
 func (r *ReconcileHawtio) logOperationResult(resource string, result controllerutil.OperationResult) {
@@ -11,3 +23,87 @@ func (r *ReconcileHawtio) logOperationResult(resource string, result controlleru
 
 	r.logger.Info("=== Resource "+resource+" Reconciliation Completed ===", "Result", result)
 }
+
+// The annotations recording the keys of the labels and annotations set by the
+// operator, so that those the operator no longer sets can be removed
+const (
+	managedLabelsAnnotation      = "hawt.io/managed-labels"
+	managedAnnotationsAnnotation = "hawt.io/managed-annotations"
+)
+
+// mergeManagedMetadata merges the labels and annotations of the blueprint into those
+// of the target, and removes those the operator previously set but no longer sets,
+// while retaining those set by others, eg. the router or a cloud controller.
+// The owned annotations are always managed by the operator, and are removed when
+// unset even if they were set before the managed keys were recorded.
+func mergeManagedMetadata(target metav1.Object, labels, annotations map[string]string, ownedAnnotations ...string) {
+	targetAnnotations := target.GetAnnotations()
+	managedLabels := splitManagedKeys(targetAnnotations[managedLabelsAnnotation])
+	managedAnnotations := append(splitManagedKeys(targetAnnotations[managedAnnotationsAnnotation]), ownedAnnotations...)
+
+	targetLabels := mergeManagedMap(target.GetLabels(), labels, managedLabels)
+	targetAnnotations = mergeManagedMap(targetAnnotations, annotations, managedAnnotations)
+
+	setManagedKeys(targetAnnotations, managedLabelsAnnotation, labels)
+	setManagedKeys(targetAnnotations, managedAnnotationsAnnotation, annotations)
+
+	target.SetLabels(targetLabels)
+	target.SetAnnotations(targetAnnotations)
+}
+
+// mergeManagedMap merges the required entries into the existing ones,
+// after deleting the managed entries that are no longer required
+func mergeManagedMap(existing, required map[string]string, managedKeys []string) map[string]string {
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	for _, key := range managedKeys {
+		if _, ok := required[key]; !ok {
+			delete(existing, key)
+		}
+	}
+	return util.MergeMap(existing, required)
+}
+
+func splitManagedKeys(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func setManagedKeys(annotations map[string]string, annotation string, managed map[string]string) {
+	keys := slices.Sorted(maps.Keys(managed))
+	keys = slices.DeleteFunc(keys, func(key string) bool {
+		return key == managedLabelsAnnotation || key == managedAnnotationsAnnotation
+	})
+	if len(keys) == 0 {
+		delete(annotations, annotation)
+		return
+	}
+	annotations[annotation] = strings.Join(keys, ",")
+}
+
+// removeOwnedResource deletes the resource if it exists and is owned by the Hawtio CR,
+// eg. when an optional resource is no longer applicable. Resources not created by
+// the operator are left alone.
+func (r *ReconcileHawtio) removeOwnedResource(ctx context.Context, hawtio *hawtiov2.Hawtio, obj client.Object) (controllerutil.OperationResult, error) {
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, nil
+		}
+		return controllerutil.OperationResultNone, err
+	}
+
+	if !metav1.IsControlledBy(obj, hawtio) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	r.logger.Info(fmt.Sprintf("Deleting stale %T %s", obj, obj.GetName()))
+	if err := r.client.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return controllerutil.OperationResultUpdated, nil
+}
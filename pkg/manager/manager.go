@@ -12,14 +12,18 @@ import (
 	errs "github.com/pkg/errors"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 
 	configv1 "github.com/openshift/api/config/v1"
 	consolev1 "github.com/openshift/api/console/v1"
 	oauthv1 "github.com/openshift/api/oauth/v1"
 	routev1 "github.com/openshift/api/route/v1"
 
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	"k8s.io/apimachinery/pkg/labels"
@@ -35,6 +39,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -43,8 +48,10 @@ import (
 	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/clients"
 	"github.com/hawtio/hawtio-operator/pkg/controller/hawtio"
+	"github.com/hawtio/hawtio-operator/pkg/migration"
 	"github.com/hawtio/hawtio-operator/pkg/updater"
 	"github.com/hawtio/hawtio-operator/pkg/util"
+	hawtiowebhook "github.com/hawtio/hawtio-operator/pkg/webhook/hawtio"
 )
 
 var log = logf.Log.WithName("manager")
@@ -79,6 +86,10 @@ func ConfigureScheme() (*runtime.Scheme, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = gatewayv1.Install(scheme)
+	if err != nil {
+		return nil, err
+	}
 
 	// Register Hawtio api scheme
 	err = apis.AddToScheme(scheme)
@@ -99,24 +110,21 @@ func createCacheOptions(watchNamespaces string, apiSpec *capabilities.ApiServerS
 	var namespaces map[string]cache.Config
 	if watchNamespaces != "" {
 		namespaces = make(map[string]cache.Config)
-		// Split the string by comma
-		nsList := strings.Split(watchNamespaces, ",")
-		// Loop through the list, trim whitespace, and add each to the map
-		for _, ns := range nsList {
-			cleanNs := strings.TrimSpace(ns)
-			if cleanNs != "" {
-				namespaces[cleanNs] = cache.Config{}
-			}
+		for _, ns := range parseWatchNamespaces(watchNamespaces) {
+			namespaces[ns] = cache.Config{}
 		}
 	}
 
 	cacheOptions := cache.Options{
 		DefaultNamespaces: namespaces,
 		ByObject: map[client.Object]cache.ByObject{
-			&appsv1.Deployment{}:    {Label: selector},
-			&corev1.ConfigMap{}:     {Label: selector},
-			&corev1.Secret{}:        {Label: selector},
-			&networkingv1.Ingress{}: {Label: selector},
+			&appsv1.Deployment{}:                     {Label: selector},
+			&corev1.ConfigMap{}:                      {Label: selector},
+			&corev1.Secret{}:                         {Label: selector},
+			&networkingv1.Ingress{}:                  {Label: selector},
+			&networkingv1.NetworkPolicy{}:            {Label: selector},
+			&policyv1.PodDisruptionBudget{}:          {Label: selector},
+			&autoscalingv2.HorizontalPodAutoscaler{}: {Label: selector},
 		},
 	}
 
@@ -128,9 +136,35 @@ func createCacheOptions(watchNamespaces string, apiSpec *capabilities.ApiServerS
 		cacheOptions.ByObject[&routev1.Route{}] = cache.ByObject{Label: selector}
 	}
 
+	// Conditional Gateway API Use
+	// Check if the cluster actually supports HTTPRoutes and BackendTLSPolicies
+	// before adding them to the Cache Watch.
+	if apiSpec.GatewayAPI {
+		log.Info("Gateway API detected. Enabling HTTPRoute support.")
+		cacheOptions.ByObject[&gatewayv1.HTTPRoute{}] = cache.ByObject{Label: selector}
+	}
+	if apiSpec.BackendTLSPolicy {
+		cacheOptions.ByObject[&gatewayv1.BackendTLSPolicy{}] = cache.ByObject{Label: selector}
+	}
+
 	return cacheOptions
 }
 
+// parseWatchNamespaces splits the comma-separated list of watched namespaces
+func parseWatchNamespaces(watchNamespaces string) []string {
+	var namespaces []string
+	// Split the string by comma
+	nsList := strings.Split(watchNamespaces, ",")
+	// Loop through the list, trim whitespace, and add each to the list
+	for _, ns := range nsList {
+		cleanNs := strings.TrimSpace(ns)
+		if cleanNs != "" {
+			namespaces = append(namespaces, cleanNs)
+		}
+	}
+	return namespaces
+}
+
 // mgrConfig options for constructing the manager
 // Use With... functions to populate
 type mgrConfig struct {
@@ -145,6 +179,7 @@ type mgrConfig struct {
 	metrics               metricserver.Options
 	updatePollingInterval time.Duration
 	registryTransport     http.RoundTripper
+	enableWebhooks        bool
 }
 
 // MgrOption function to populate manager config
@@ -229,6 +264,13 @@ func WithRegistryTransport(transport http.RoundTripper) MgrOption {
 	}
 }
 
+// WithWebhooks enables serving the Hawtio admission webhooks
+func WithWebhooks(enabled bool) MgrOption {
+	return func(c *mgrConfig) {
+		c.enableWebhooks = enabled
+	}
+}
+
 // New creates a controller-runtime Manager
 // - Uses the custom scheme
 // - Configures 'app=hawtio' Cache Filtering (Memory Optimization)
@@ -300,12 +342,20 @@ func New(mgrOptions ...MgrOption) (manager.Manager, error) {
 	}
 
 	// construct the manager
-	mgr, err := manager.New(mc.restConfig, manager.Options{
+	options := manager.Options{
 		Scheme:                  mc.scheme,
 		Cache:                   cacheOptions,
 		LeaderElectionNamespace: mc.operatorPodNS,
 		Metrics:                 mc.metrics,
-	})
+	}
+
+	if mc.enableWebhooks {
+		// Serves on the default port (9443) with the certificates
+		// mounted in the default directory (/tmp/k8s-webhook-server/serving-certs)
+		options.WebhookServer = webhook.NewServer(webhook.Options{})
+	}
+
+	mgr, err := manager.New(mc.restConfig, options)
 	if err != nil {
 		return nil, fmt.Errorf("unable to construct manager: %w", err)
 	}
@@ -343,6 +393,25 @@ func New(mgrOptions ...MgrOption) (manager.Manager, error) {
 		return nil, err
 	}
 
+	// Register the storage migration of the Hawtio objects with the manager
+	storageMigrator := &migration.StorageMigrator{
+		Client:     mgr.GetClient(),
+		Reader:     mgr.GetAPIReader(),
+		Namespaces: parseWatchNamespaces(mc.watchNamespaces),
+		Logger:     log.WithName("Storage Migrator"),
+	}
+	if err := mgr.Add(storageMigrator); err != nil {
+		return nil, errs.Wrap(err, "Failed to register the storage migrator")
+	}
+
+	// Register the hawtio admission webhooks with the manager
+	if mc.enableWebhooks {
+		log.Info("Admission webhooks are enabled")
+		if err := hawtiowebhook.Add(mgr); err != nil {
+			return nil, errs.Wrap(err, "Failed to register the Hawtio webhooks")
+		}
+	}
+
 	return mgr, nil
 }
 
@@ -0,0 +1,322 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+// CRDName is the name of the Hawtio custom resource definition
+const CRDName = "hawtios.hawt.io"
+
+// ProgressAnnotation records, on the Hawtio CRD, the progress of an
+// ongoing storage migration so that it can be resumed after a restart
+const ProgressAnnotation = "hawt.io/storage-migration"
+
+// CompletionAnnotation records, on the Hawtio CRD, the namespaces whose
+// Hawtio objects have all been migrated, when the operator does not watch
+// all the namespaces and the stored versions of the CRD cannot be trimmed
+const CompletionAnnotation = "hawt.io/storage-migration-completed"
+
+const (
+	defaultPageSize      = 100
+	defaultRetryInterval = time.Minute
+)
+
+// Progress is the state of an ongoing storage migration
+type Progress struct {
+	// The namespace being migrated, empty if migrating across all namespaces
+	Namespace string `json:"namespace,omitempty"`
+	// The continue token of the next page of Hawtio objects to migrate
+	Continue string `json:"continue,omitempty"`
+	// The number of Hawtio objects migrated so far
+	Migrated int `json:"migrated"`
+}
+
+// Completion is the state of the storage migrations completed in
+// the namespaces watched by the operator
+type Completion struct {
+	// The storage version the Hawtio objects have been migrated to
+	Version string `json:"version"`
+	// The namespaces whose Hawtio objects have all been migrated
+	Namespaces []string `json:"namespaces"`
+}
+
+// StorageMigrator rewrites the Hawtio objects persisted at older versions
+// of the API at the current storage version, then trims the stored versions
+// of the Hawtio CRD, so that the older versions can eventually stop being served.
+type StorageMigrator struct {
+	// Client writes the Hawtio objects and the CRD
+	Client client.Client
+	// Reader lists the Hawtio objects, page by page, from the API server
+	Reader client.Reader
+	// Namespaces restricts the migration to the watched namespaces.
+	// The stored versions of the CRD are only trimmed if it is empty,
+	// ie. the operator watches all the namespaces.
+	Namespaces    []string
+	PageSize      int64
+	RetryInterval time.Duration
+	Logger        logr.Logger
+}
+
+// Start fulfills the manager.Runnable interface.
+// The migration is retried until it completes or the context is cancelled.
+func (m *StorageMigrator) Start(ctx context.Context) error {
+	retryInterval := m.RetryInterval
+	if retryInterval == 0 {
+		retryInterval = defaultRetryInterval
+	}
+
+	for {
+		err := m.Migrate(ctx)
+		if err == nil {
+			return nil
+		}
+
+		m.Logger.Error(err, "Storage Migrator: Migration failed. Retrying.", "interval", retryInterval.String())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Migrate performs the storage migration, resuming from the progress
+// recorded on the CRD if any.
+func (m *StorageMigrator) Migrate(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Reader.Get(ctx, client.ObjectKey{Name: CRDName}, crd); err != nil {
+		return fmt.Errorf("failed to get CRD %s: %w", CRDName, err)
+	}
+
+	storageVersion := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+		}
+	}
+	if storageVersion != hawtiov2.SchemeGroupVersion.Version {
+		return fmt.Errorf("unexpected storage version %q of CRD %s", storageVersion, CRDName)
+	}
+
+	if slices.Equal(crd.Status.StoredVersions, []string{storageVersion}) {
+		m.Logger.V(util.DebugLogLevel).Info("Storage Migrator: All Hawtio objects are stored at the storage version", "version", storageVersion)
+		return nil
+	}
+
+	completion := &Completion{Version: storageVersion}
+	if len(m.Namespaces) > 0 {
+		recorded := &Completion{}
+		if err := loadAnnotation(crd, CompletionAnnotation, recorded); err != nil {
+			m.Logger.Error(err, "Storage Migrator: Ignoring invalid migration completion.")
+		} else if recorded.Version == storageVersion {
+			completion = recorded
+		}
+	}
+
+	namespaces := []string{""}
+	if len(m.Namespaces) > 0 {
+		namespaces = nil
+		for _, namespace := range slices.Sorted(slices.Values(m.Namespaces)) {
+			if !slices.Contains(completion.Namespaces, namespace) {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+		if len(namespaces) == 0 {
+			m.Logger.V(util.DebugLogLevel).Info("Storage Migrator: All Hawtio objects in the watched namespaces are stored at the storage version", "version", storageVersion)
+			return nil
+		}
+	}
+
+	var progress *Progress
+	if _, ok := crd.Annotations[ProgressAnnotation]; ok {
+		progress = &Progress{}
+		if err := loadAnnotation(crd, ProgressAnnotation, progress); err != nil {
+			m.Logger.Error(err, "Storage Migrator: Ignoring invalid migration progress. Restarting from the beginning.")
+			progress = nil
+		}
+	}
+
+	m.Logger.Info("Storage Migrator: Migrating Hawtio objects to the storage version",
+		"storedVersions", crd.Status.StoredVersions, "version", storageVersion, "resuming", progress != nil)
+
+	if progress != nil && !slices.Contains(namespaces, progress.Namespace) {
+		// The watched namespaces have changed since the progress was recorded
+		progress = nil
+	}
+
+	migrated, skipped := 0, 0
+	if progress != nil {
+		migrated = progress.Migrated
+	}
+
+	for _, namespace := range namespaces {
+		token := ""
+		if progress != nil {
+			if namespace < progress.Namespace {
+				// Already migrated before the restart
+				continue
+			}
+			if namespace == progress.Namespace {
+				token = progress.Continue
+			}
+		}
+
+		var err error
+		migrated, skipped, err = m.migrateNamespace(ctx, crd, namespace, token, migrated, skipped)
+		if err != nil {
+			return err
+		}
+	}
+
+	if skipped > 0 {
+		// Some objects may still be stored at older versions. They are
+		// migrated again at the next start of the operator.
+		m.Logger.Info("Storage Migrator: Some Hawtio objects could not be migrated. The stored versions of the CRD are left unchanged.",
+			"migrated", migrated, "skipped", skipped, "storedVersions", crd.Status.StoredVersions)
+		return m.saveAnnotation(ctx, crd, ProgressAnnotation, nil)
+	}
+
+	if len(m.Namespaces) == 0 {
+		// All the Hawtio objects are now stored at the storage version
+		original := crd.DeepCopy()
+		crd.Status.StoredVersions = []string{storageVersion}
+		if err := m.Client.Status().Patch(ctx, crd, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to trim the stored versions of CRD %s: %w", CRDName, err)
+		}
+	} else {
+		m.Logger.Info("Storage Migrator: Not watching all namespaces. The stored versions of the CRD are left unchanged.",
+			"storedVersions", crd.Status.StoredVersions)
+
+		// Record the migrated namespaces so that they are not migrated again
+		completion.Namespaces = slices.Sorted(slices.Values(append(completion.Namespaces, namespaces...)))
+		if err := m.saveAnnotation(ctx, crd, CompletionAnnotation, completion); err != nil {
+			return err
+		}
+	}
+
+	if err := m.saveAnnotation(ctx, crd, ProgressAnnotation, nil); err != nil {
+		return err
+	}
+
+	m.Logger.Info("Storage Migrator: Migration complete", "migrated", migrated, "version", storageVersion)
+
+	return nil
+}
+
+// migrateNamespace rewrites the Hawtio objects of the namespace, page by page,
+// recording the progress on the CRD after each page. The objects the API
+// server rejects are skipped, and counted, so that they do not prevent the
+// other objects from being migrated.
+func (m *StorageMigrator) migrateNamespace(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, namespace, token string, migrated, skipped int) (int, int, error) {
+	pageSize := m.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	for {
+		list := &hawtiov2.HawtioList{}
+		err := m.Reader.List(ctx, list, client.InNamespace(namespace), client.Limit(pageSize), client.Continue(token))
+		if kerrors.IsResourceExpired(err) && token != "" {
+			// The continue token has expired since the progress was
+			// recorded. Rewriting is idempotent so start again.
+			m.Logger.Info("Storage Migrator: Continue token expired. Restarting the namespace.", "namespace", namespace)
+			token = ""
+			continue
+		}
+		if err != nil {
+			return migrated, skipped, fmt.Errorf("failed to list Hawtio objects: %w", err)
+		}
+
+		for i := range list.Items {
+			hawtio := &list.Items[i]
+			// An unchanged update is enough for the API server to
+			// persist the object at the storage version, and is a
+			// no-op for objects already stored at that version
+			err := m.Client.Update(ctx, hawtio)
+			switch {
+			case err == nil, kerrors.IsConflict(err), kerrors.IsNotFound(err):
+				// Conflicting objects have already been rewritten
+				// by someone else, and deleted ones are gone
+				migrated++
+			case isTransient(err):
+				return migrated, skipped, fmt.Errorf("failed to migrate Hawtio %s/%s: %w", hawtio.Namespace, hawtio.Name, err)
+			default:
+				m.Logger.Error(err, "Storage Migrator: Skipping Hawtio object rejected by the API server", "namespace", hawtio.Namespace, "name", hawtio.Name)
+				skipped++
+			}
+		}
+
+		token = list.Continue
+		if token == "" {
+			return migrated, skipped, nil
+		}
+
+		progress := &Progress{Namespace: namespace, Continue: token, Migrated: migrated}
+		if err := m.saveAnnotation(ctx, crd, ProgressAnnotation, progress); err != nil {
+			return migrated, skipped, err
+		}
+
+		m.Logger.Info("Storage Migrator: Migration in progress", "namespace", namespace, "migrated", migrated)
+	}
+}
+
+// isTransient returns whether the error may not occur again if the request is retried
+func isTransient(err error) bool {
+	return kerrors.IsServerTimeout(err) || kerrors.IsTimeout(err) || kerrors.IsTooManyRequests(err) ||
+		kerrors.IsServiceUnavailable(err) || kerrors.IsInternalError(err) || kerrors.IsUnexpectedServerError(err)
+}
+
+// loadAnnotation unmarshals the value of the annotation of the CRD, if any, into the state
+func loadAnnotation(crd *apiextensionsv1.CustomResourceDefinition, annotation string, state any) error {
+	data, ok := crd.Annotations[annotation]
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		return fmt.Errorf("failed to unmarshal the %s annotation: %w", annotation, err)
+	}
+
+	return nil
+}
+
+// saveAnnotation records the state in the annotation of the CRD, or removes it if nil
+func (m *StorageMigrator) saveAnnotation(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, annotation string, state any) error {
+	original := crd.DeepCopy()
+
+	if state == nil {
+		if _, ok := crd.Annotations[annotation]; !ok {
+			return nil
+		}
+		delete(crd.Annotations, annotation)
+	} else {
+		data, err := json.Marshal(state)
+		if err != nil {
+			return err
+		}
+		if crd.Annotations == nil {
+			crd.Annotations = map[string]string{}
+		}
+		crd.Annotations[annotation] = string(data)
+	}
+
+	if err := m.Client.Patch(ctx, crd, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to record the %s annotation on CRD %s: %w", annotation, CRDName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+func newTestCRD(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: CRDName,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true},
+				{Name: "v1", Served: true},
+				{Name: "v2", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: storedVersions,
+		},
+	}
+}
+
+func newTestHawtio(namespace, name string) *hawtiov2.Hawtio {
+	return &hawtiov2.Hawtio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func newTestMigrator(t *testing.T, namespaces []string, objs ...client.Object) (*StorageMigrator, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, hawtiov2.SchemeBuilder.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).
+		Build()
+
+	return &StorageMigrator{
+		Client:     cl,
+		Reader:     cl,
+		Namespaces: namespaces,
+		Logger:     testr.New(t),
+	}, cl
+}
+
+func getResourceVersion(t *testing.T, cl client.Client, obj client.Object) string {
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj))
+	return obj.GetResourceVersion()
+}
+
+func TestMigrateAllNamespaces(t *testing.T) {
+	hawtio1 := newTestHawtio("ns1", "hawtio")
+	hawtio2 := newTestHawtio("ns2", "hawtio")
+	migrator, cl := newTestMigrator(t, nil, newTestCRD("v1", "v2"), hawtio1, hawtio2)
+
+	rv1 := getResourceVersion(t, cl, hawtio1)
+	rv2 := getResourceVersion(t, cl, hawtio2)
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	assert.NotEqual(t, rv1, getResourceVersion(t, cl, hawtio1))
+	assert.NotEqual(t, rv2, getResourceVersion(t, cl, hawtio2))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.Equal(t, []string{"v2"}, crd.Status.StoredVersions)
+	assert.NotContains(t, crd.Annotations, ProgressAnnotation)
+}
+
+func TestMigrateWatchedNamespaces(t *testing.T) {
+	hawtio1 := newTestHawtio("ns1", "hawtio")
+	hawtio2 := newTestHawtio("ns2", "hawtio")
+	migrator, cl := newTestMigrator(t, []string{"ns1"}, newTestCRD("v1", "v2"), hawtio1, hawtio2)
+
+	rv1 := getResourceVersion(t, cl, hawtio1)
+	rv2 := getResourceVersion(t, cl, hawtio2)
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	assert.NotEqual(t, rv1, getResourceVersion(t, cl, hawtio1))
+	assert.Equal(t, rv2, getResourceVersion(t, cl, hawtio2))
+
+	// Objects in other namespaces may still be stored at older versions
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.Equal(t, []string{"v1", "v2"}, crd.Status.StoredVersions)
+}
+
+func TestMigrateResumesFromProgress(t *testing.T) {
+	crd := newTestCRD("v1alpha1", "v1", "v2")
+	crd.Annotations = map[string]string{
+		ProgressAnnotation: `{"namespace":"ns2","migrated":1}`,
+	}
+
+	hawtio1 := newTestHawtio("ns1", "hawtio")
+	hawtio2 := newTestHawtio("ns2", "hawtio")
+	migrator, cl := newTestMigrator(t, []string{"ns2", "ns1"}, crd, hawtio1, hawtio2)
+
+	rv1 := getResourceVersion(t, cl, hawtio1)
+	rv2 := getResourceVersion(t, cl, hawtio2)
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	// ns1 was migrated before the restart
+	assert.Equal(t, rv1, getResourceVersion(t, cl, hawtio1))
+	assert.NotEqual(t, rv2, getResourceVersion(t, cl, hawtio2))
+
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.NotContains(t, crd.Annotations, ProgressAnnotation)
+}
+
+func TestMigrateNothingToMigrate(t *testing.T) {
+	hawtio := newTestHawtio("ns1", "hawtio")
+	migrator, cl := newTestMigrator(t, nil, newTestCRD("v2"), hawtio)
+
+	rv := getResourceVersion(t, cl, hawtio)
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	assert.Equal(t, rv, getResourceVersion(t, cl, hawtio))
+}
+
+func TestMigrateWatchedNamespacesOnce(t *testing.T) {
+	hawtio1 := newTestHawtio("ns1", "hawtio")
+	hawtio2 := newTestHawtio("ns2", "hawtio")
+	migrator, cl := newTestMigrator(t, []string{"ns1"}, newTestCRD("v1", "v2"), hawtio1, hawtio2)
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.JSONEq(t, `{"version":"v2","namespaces":["ns1"]}`, crd.Annotations[CompletionAnnotation])
+
+	// The completed namespaces are not migrated again at the next start
+	rv1 := getResourceVersion(t, cl, hawtio1)
+	require.NoError(t, migrator.Migrate(context.TODO()))
+	assert.Equal(t, rv1, getResourceVersion(t, cl, hawtio1))
+
+	// Newly watched namespaces are migrated
+	rv2 := getResourceVersion(t, cl, hawtio2)
+	migrator.Namespaces = []string{"ns1", "ns2"}
+	require.NoError(t, migrator.Migrate(context.TODO()))
+	assert.Equal(t, rv1, getResourceVersion(t, cl, hawtio1))
+	assert.NotEqual(t, rv2, getResourceVersion(t, cl, hawtio2))
+
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.JSONEq(t, `{"version":"v2","namespaces":["ns1","ns2"]}`, crd.Annotations[CompletionAnnotation])
+}
+
+func TestMigrateSkipsRejectedObjects(t *testing.T) {
+	rejected := newTestHawtio("ns1", "rejected")
+	hawtio := newTestHawtio("ns2", "hawtio")
+	migrator, cl := newTestMigrator(t, nil, newTestCRD("v1", "v2"), rejected, hawtio)
+
+	rv := getResourceVersion(t, cl, hawtio)
+
+	migrator.Client = interceptor.NewClient(cl.(client.WithWatch), interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if obj.GetName() == rejected.Name {
+				return kerrors.NewInvalid(hawtiov2.SchemeGroupVersion.WithKind("Hawtio").GroupKind(), obj.GetName(), nil)
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	})
+
+	require.NoError(t, migrator.Migrate(context.TODO()))
+
+	assert.NotEqual(t, rv, getResourceVersion(t, cl, hawtio))
+
+	// The rejected object may still be stored at an older version
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: CRDName}, crd))
+	assert.Equal(t, []string{"v1", "v2"}, crd.Status.StoredVersions)
+	assert.NotContains(t, crd.Annotations, ProgressAnnotation)
+}
@@ -1,10 +1,11 @@
 package openshift
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	consolev1 "github.com/openshift/api/console/v1"
-	routev1 "github.com/openshift/api/route/v1"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 
@@ -26,7 +27,7 @@ func NewDefaultConsoleLink(name string) *consolev1.ConsoleLink {
 }
 
 // NewApplicationMenuLink creates an ApplicationMenu ConsoleLink instance
-func NewApplicationMenuLink(name string, namespace string, route *routev1.Route, config *hawtiov2.HawtioConfig) *consolev1.ConsoleLink {
+func NewApplicationMenuLink(name string, namespace string, consoleURL string, config *hawtiov2.HawtioConfig) *consolev1.ConsoleLink {
 	consoleLink := NewDefaultConsoleLink(name)
 
 	labels := resources.LabelsForHawtio(name)
@@ -36,13 +37,13 @@ func NewApplicationMenuLink(name string, namespace string, route *routev1.Route,
 		ApplicationMenu: &consolev1.ApplicationMenuSpec{},
 	}
 
-	UpdateApplicationMenuLink(consoleLink, route, config)
+	UpdateApplicationMenuLink(consoleLink, consoleURL, config)
 
 	return consoleLink
 }
 
 // UpdateApplicationMenuLink updates the ApplicationMenu ConsoleLink properties
-func UpdateApplicationMenuLink(consoleLink *consolev1.ConsoleLink, route *routev1.Route, config *hawtiov2.HawtioConfig) {
+func UpdateApplicationMenuLink(consoleLink *consolev1.ConsoleLink, consoleURL string, config *hawtiov2.HawtioConfig) {
 	consoleLink.Spec.Location = consolev1.ApplicationMenu
 	consoleLink.Spec.Link.Text = config.Online.ConsoleLink.Text
 
@@ -50,17 +51,17 @@ func UpdateApplicationMenuLink(consoleLink *consolev1.ConsoleLink, route *routev
 		consoleLink.Spec.Link.Text = defaultConsoleLinkText
 	}
 
-	consoleLink.Spec.Link.Href = "https://" + route.Spec.Host
+	consoleLink.Spec.Link.Href = consoleURL
 
 	if consoleLink.Spec.ApplicationMenu == nil {
 		consoleLink.Spec.ApplicationMenu = &consolev1.ApplicationMenuSpec{}
 	}
 	consoleLink.Spec.ApplicationMenu.Section = config.Online.ConsoleLink.Section
-	consoleLink.Spec.ApplicationMenu.ImageURL = "https://" + route.Spec.Host + config.Online.ConsoleLink.ImageRelativePath
+	consoleLink.Spec.ApplicationMenu.ImageURL = strings.TrimSuffix(consoleURL, "/") + config.Online.ConsoleLink.ImageRelativePath
 }
 
 // NewNamespaceDashboardLink creates a NamespaceDashboard ConsoleLink instance
-func NewNamespaceDashboardLink(name string, namespace string, route *routev1.Route, config *hawtiov2.HawtioConfig) *consolev1.ConsoleLink {
+func NewNamespaceDashboardLink(name string, namespace string, consoleURL string, config *hawtiov2.HawtioConfig) *consolev1.ConsoleLink {
 	consoleLink := NewDefaultConsoleLink(name)
 
 	labels := resources.LabelsForHawtio(name)
@@ -72,13 +73,13 @@ func NewNamespaceDashboardLink(name string, namespace string, route *routev1.Rou
 		},
 	}
 
-	UpdateNamespaceDashboardLink(consoleLink, route, config)
+	UpdateNamespaceDashboardLink(consoleLink, consoleURL, config)
 
 	return consoleLink
 }
 
 // UpdateNamespaceDashboardLink updates the NamespaceDashboard ConsoleLink properties
-func UpdateNamespaceDashboardLink(consoleLink *consolev1.ConsoleLink, route *routev1.Route, config *hawtiov2.HawtioConfig) {
+func UpdateNamespaceDashboardLink(consoleLink *consolev1.ConsoleLink, consoleURL string, config *hawtiov2.HawtioConfig) {
 	consoleLink.Spec.Location = consolev1.NamespaceDashboard
 	consoleLink.Spec.Link.Text = config.Online.ConsoleLink.Text
 
@@ -86,7 +87,7 @@ func UpdateNamespaceDashboardLink(consoleLink *consolev1.ConsoleLink, route *rou
 		consoleLink.Spec.Link.Text = defaultConsoleLinkText
 	}
 
-	consoleLink.Spec.Link.Href = "https://" + route.Spec.Host
+	consoleLink.Spec.Link.Href = consoleURL
 	// ApplicationMenu can be set when the Hawtio type changes from 'cluster' to 'namespace'
 	consoleLink.Spec.ApplicationMenu = nil
 }
@@ -24,6 +24,10 @@ const (
 	GatewayReadinessPeriodValue = 30
 	// GatewayLivenessPeriodValue is the default for Gateway's Liveness period value
 	GatewayLivenessPeriodValue = 120
+	// StartupPeriodValue is the default for the Startup period value
+	StartupPeriodValue = 10
+	// StartupFailureThresholdValue is the default for the Startup failure threshold value
+	StartupFailureThresholdValue = 30
 
 	containerPortName        = "nginx"
 	containerGatewayPortName = "express"
@@ -99,34 +103,30 @@ func newHawtioContainer(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServer
 		livenessPeriodValue = *hawtio.Spec.HealthChecks.OnlineLivenessPeriod
 	}
 
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Port:   intstr.FromString(containerPortName),
+			Path:   "/online",
+			Scheme: connect.Protocol,
+		},
+	}
+	probes := hawtio.Spec.Probes.Online
+
 	container := corev1.Container{
 		Name:            hawtio.Name + "-container",
-		Image:           getHawtioImageFor(imageVersion, imageRepository),
-		ImagePullPolicy: getImagePullPolicy(),
+		Image:           getHawtioImageFor(hawtio, imageVersion, imageRepository),
+		ImagePullPolicy: getImagePullPolicy(hawtio.Spec.Images.Online),
 		Env:             envVars,
-		ReadinessProbe: &corev1.Probe{
+		ReadinessProbe: newProbe(handler, corev1.Probe{
 			InitialDelaySeconds: 5,
 			TimeoutSeconds:      1,
 			PeriodSeconds:       readinessPeriodValue,
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Port:   intstr.FromString(containerPortName),
-					Path:   "/online",
-					Scheme: connect.Protocol,
-				},
-			},
-		},
-		LivenessProbe: &corev1.Probe{
+		}, probes.Readiness),
+		LivenessProbe: newProbe(handler, corev1.Probe{
 			TimeoutSeconds: 1,
 			PeriodSeconds:  livenessPeriodValue,
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Port:   intstr.FromString(containerPortName),
-					Path:   "/online",
-					Scheme: connect.Protocol,
-				},
-			},
-		},
+		}, probes.Liveness),
+		StartupProbe: newStartupProbe(handler, probes.Startup),
 		Ports: []corev1.ContainerPort{
 			{
 				Name:          containerPortName,
@@ -134,7 +134,7 @@ func newHawtioContainer(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServer
 				Protocol:      "TCP",
 			},
 		},
-		Resources: hawtio.Spec.Resources,
+		Resources: containerResources(hawtio, hawtio.Spec.ContainerResources.Online),
 	}
 
 	return container
@@ -182,10 +182,19 @@ func newGatewayContainer(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServe
 		livenessPeriodValue = *hawtio.Spec.HealthChecks.GatewayLivenessPeriod
 	}
 
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Port:   intstr.FromString(containerGatewayPortName),
+			Path:   "/status",
+			Scheme: connect.Protocol,
+		},
+	}
+	probes := hawtio.Spec.Probes.Gateway
+
 	container := corev1.Container{
 		Name:            hawtio.Name + "-gateway-container",
-		Image:           getGatewayImageFor(imageVersion, imageGatewayRepository),
-		ImagePullPolicy: getImagePullPolicy(),
+		Image:           getGatewayImageFor(hawtio, imageVersion, imageGatewayRepository),
+		ImagePullPolicy: getImagePullPolicy(hawtio.Spec.Images.Gateway),
 		Env:             envVars,
 		Ports: []corev1.ContainerPort{
 			{
@@ -194,35 +203,69 @@ func newGatewayContainer(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServe
 				Protocol:      "TCP",
 			},
 		},
-		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Port:   intstr.FromString(containerGatewayPortName),
-					Path:   "/status",
-					Scheme: connect.Protocol,
-				},
-			},
+		LivenessProbe: newProbe(handler, corev1.Probe{
 			PeriodSeconds:  livenessPeriodValue,
 			TimeoutSeconds: 1,
-		},
-		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Port:   intstr.FromString(containerGatewayPortName),
-					Path:   "/status",
-					Scheme: connect.Protocol,
-				},
-			},
+		}, probes.Liveness),
+		ReadinessProbe: newProbe(handler, corev1.Probe{
 			InitialDelaySeconds: 5,
 			PeriodSeconds:       readinessPeriodValue,
 			TimeoutSeconds:      1,
-		},
-		Resources: hawtio.Spec.Resources,
+		}, probes.Readiness),
+		StartupProbe: newStartupProbe(handler, probes.Startup),
+		Resources:    containerResources(hawtio, hawtio.Spec.ContainerResources.Gateway),
 	}
 
 	return container
 }
 
+// newProbe returns a probe running the handler, with the settings
+// specified in the Hawtio CR taking precedence over the defaults
+func newProbe(handler corev1.ProbeHandler, defaults corev1.Probe, settings hawtiov2.HawtioProbe) *corev1.Probe {
+	probe := defaults
+	probe.ProbeHandler = handler
+
+	if settings.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *settings.InitialDelaySeconds
+	}
+	if settings.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *settings.TimeoutSeconds
+	}
+	if settings.PeriodSeconds != nil {
+		probe.PeriodSeconds = *settings.PeriodSeconds
+	}
+	if settings.SuccessThreshold != nil {
+		probe.SuccessThreshold = *settings.SuccessThreshold
+	}
+	if settings.FailureThreshold != nil {
+		probe.FailureThreshold = *settings.FailureThreshold
+	}
+
+	return &probe
+}
+
+// newStartupProbe returns the startup probe of a container, only if specified in the Hawtio CR
+func newStartupProbe(handler corev1.ProbeHandler, settings *hawtiov2.HawtioProbe) *corev1.Probe {
+	if settings == nil {
+		return nil
+	}
+
+	return newProbe(handler, corev1.Probe{
+		TimeoutSeconds:   1,
+		PeriodSeconds:    StartupPeriodValue,
+		FailureThreshold: StartupFailureThresholdValue,
+	}, *settings)
+}
+
+// containerResources returns the resources specified for a container,
+// falling back to the resources shared by all the containers
+func containerResources(hawtio *hawtiov2.Hawtio, resources *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources != nil {
+		return *resources
+	}
+	return hawtio.Spec.Resources
+}
+
 func newHawtioEnvVars(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, openShiftConsoleURL string) []corev1.EnvVar {
 	var envVars []corev1.EnvVar
 
@@ -252,16 +295,25 @@ func newGatewayEnvVars(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerS
 	return envVars
 }
 
-func getHawtioImageFor(tag string, imageRepository string) string {
-	return getImageFor(tag, imageRepository, "IMAGE_REPOSITORY", "quay.io/hawtio/online")
+func getHawtioImageFor(hawtio *hawtiov2.Hawtio, tag string, imageRepository string) string {
+	return getImageFor(hawtio.Spec.Images.Online, tag, imageRepository, "IMAGE_REPOSITORY", "quay.io/hawtio/online")
 }
 
-func getGatewayImageFor(tag string, gatewayImgRepository string) string {
-	return getImageFor(tag, gatewayImgRepository, "GATEWAY_IMAGE_REPOSITORY", "quay.io/hawtio/online-gateway")
+func getGatewayImageFor(hawtio *hawtiov2.Hawtio, tag string, gatewayImgRepository string) string {
+	return getImageFor(hawtio.Spec.Images.Gateway, tag, gatewayImgRepository, "GATEWAY_IMAGE_REPOSITORY", "quay.io/hawtio/online-gateway")
 }
 
-func getImageFor(tag string, imgRepo string, envVar string, defaultVal string) string {
-	repository := os.Getenv(envVar)
+// IsImagePinned returns whether the image is pinned by the Hawtio CR, rather than
+// chosen by the operator, in which case it must not be updated by the update poller
+func IsImagePinned(image hawtiov2.HawtioImage) bool {
+	return image.Repository != "" || image.Tag != "" || image.Digest != ""
+}
+
+func getImageFor(image hawtiov2.HawtioImage, tag string, imgRepo string, envVar string, defaultVal string) string {
+	repository := image.Repository
+	if repository == "" {
+		repository = os.Getenv(envVar)
+	}
 	if repository == "" {
 		if imgRepo != "" {
 			repository = imgRepo
@@ -270,6 +322,13 @@ func getImageFor(tag string, imgRepo string, envVar string, defaultVal string) s
 		}
 	}
 
+	if image.Digest != "" {
+		return repository + "@" + image.Digest
+	}
+	if image.Tag != "" {
+		tag = image.Tag
+	}
+
 	if strings.HasPrefix(tag, "sha256:") {
 		// tag is a sha checksum tag
 		return repository + "@" + tag
@@ -278,7 +337,11 @@ func getImageFor(tag string, imgRepo string, envVar string, defaultVal string) s
 	return repository + ":" + tag
 }
 
-func getImagePullPolicy() corev1.PullPolicy {
+func getImagePullPolicy(image hawtiov2.HawtioImage) corev1.PullPolicy {
+	if image.PullPolicy != "" {
+		return image.PullPolicy
+	}
+
 	pullPolicy := os.Getenv("IMAGE_PULL_POLICY")
 
 	if pullPolicy == "" {
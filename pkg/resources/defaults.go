@@ -0,0 +1,49 @@
+package resources
+
+import (
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+// ApplyDefaults fills the unset fields of the Hawtio spec with the defaults
+// used by the operator. It is shared by the defaulting webhook, which persists
+// an explicit spec at admission, and the reconciler, which only applies the
+// defaults in memory to CRs admitted without the webhook being installed.
+func ApplyDefaults(hawtio *hawtiov2.Hawtio) {
+	spec := &hawtio.Spec
+
+	if spec.Type == "" {
+		spec.Type = hawtiov2.ClusterHawtioDeploymentType
+	}
+
+	if spec.Replicas == nil {
+		replicas := int32(1)
+		spec.Replicas = &replicas
+	}
+
+	if spec.Auth.InternalSSL == nil {
+		internalSSL := true
+		spec.Auth.InternalSSL = &internalSSL
+	}
+
+	if spec.Logging.OnlineLogLevel == "" {
+		spec.Logging.OnlineLogLevel = HawtioOnlineLogLvlValue
+	}
+	if spec.Logging.GatewayLogLevel == "" {
+		spec.Logging.GatewayLogLevel = GatewayLogLvlValue
+	}
+	if spec.Logging.MaskIPAddresses == "" {
+		spec.Logging.MaskIPAddresses = GatewayMaskIPValue
+	}
+
+	spec.HealthChecks.OnlineReadinessPeriod = defaultPeriod(spec.HealthChecks.OnlineReadinessPeriod, OnlineReadinessPeriodValue)
+	spec.HealthChecks.OnlineLivenessPeriod = defaultPeriod(spec.HealthChecks.OnlineLivenessPeriod, OnlineLivenessPeriodValue)
+	spec.HealthChecks.GatewayReadinessPeriod = defaultPeriod(spec.HealthChecks.GatewayReadinessPeriod, GatewayReadinessPeriodValue)
+	spec.HealthChecks.GatewayLivenessPeriod = defaultPeriod(spec.HealthChecks.GatewayLivenessPeriod, GatewayLivenessPeriodValue)
+}
+
+func defaultPeriod(period *int32, value int32) *int32 {
+	if period != nil {
+		return period
+	}
+	return &value
+}
@@ -3,6 +3,7 @@ package resources
 import (
 	"fmt"
 	"path"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/go-logr/logr"
@@ -33,6 +34,7 @@ const (
 	serverRootDirectory                       = "/usr/share/nginx/html"
 	OnlineDigestAnnotation                    = "hawtio.io/online-digest"
 	GatewayDigestAnnotation                   = "hawtio.io/gateway-digest"
+	defaultProgressDeadlineSeconds            = 600
 )
 
 func NewDefaultDeployment(hawtio *hawtiov2.Hawtio) *appsv1.Deployment {
@@ -51,7 +53,8 @@ func NewDeployment(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec,
 	if err != nil {
 		return nil, err
 	}
-	return newDeployment(hawtio, hawtio.Spec.Replicas, podTemplateSpec, log), nil
+	replicas := DesiredReplicas(hawtio)
+	return newDeployment(hawtio, &replicas, podTemplateSpec, log), nil
 }
 
 func newDeployment(hawtio *hawtiov2.Hawtio, replicas *int32, pts corev1.PodTemplateSpec, log logr.Logger) *appsv1.Deployment {
@@ -77,14 +80,40 @@ func newDeployment(hawtio *hawtiov2.Hawtio, replicas *int32, pts corev1.PodTempl
 		Selector: &metav1.LabelSelector{
 			MatchLabels: deployment.Labels,
 		},
-		Template: pts,
-		Strategy: appsv1.DeploymentStrategy{
-			Type: appsv1.RollingUpdateDeploymentStrategyType,
-		},
+		Template:                pts,
+		Strategy:                newDeploymentStrategy(hawtio.Spec.Rollout),
+		MinReadySeconds:         hawtio.Spec.Rollout.MinReadySeconds,
+		ProgressDeadlineSeconds: hawtio.Spec.Rollout.ProgressDeadlineSeconds,
+		RevisionHistoryLimit:    hawtio.Spec.Rollout.RevisionHistoryLimit,
 	}
 	return deployment
 }
 
+func newDeploymentStrategy(rollout hawtiov2.HawtioRollout) appsv1.DeploymentStrategy {
+	if rollout.Strategy == appsv1.RecreateDeploymentStrategyType {
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	}
+
+	strategy := appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+	}
+	if rollout.MaxSurge != nil || rollout.MaxUnavailable != nil {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       rollout.MaxSurge,
+			MaxUnavailable: rollout.MaxUnavailable,
+		}
+	}
+	return strategy
+}
+
+// ProgressDeadline returns the duration the rollout of the Hawtio deployment
+// can take to make progress before the console is considered failed
+func ProgressDeadline(hawtio *hawtiov2.Hawtio) time.Duration {
+	return time.Duration(pointer.Int32PtrDerefOr(hawtio.Spec.Rollout.ProgressDeadlineSeconds, defaultProgressDeadlineSeconds)) * time.Second
+}
+
 /**
  *
  * Creates a new pod template comprising 2 constainers:
@@ -96,16 +125,36 @@ func newDeployment(hawtio *hawtiov2.Hawtio, replicas *int32, pts corev1.PodTempl
 func newPodTemplateSpec(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, openShiftConsoleURL string, configMapVersion string, clientCertSecretVersion string, buildVariables util.BuildVariables, log logr.Logger) (corev1.PodTemplateSpec, error) {
 	log.V(util.DebugLogLevel).Info("New Pod Template Spec")
 
-	hawtioVersion := buildVariables.GetOnlineVersion()
+	release, err := SelectOperandRelease(hawtio)
+	if err != nil {
+		// Reported in the Hawtio status, the console is deployed
+		// with the release the operator is built for instead
+		log.V(util.DebugLogLevel).Info(fmt.Sprintf("Ignoring version: %s", err.Error()))
+	}
+
+	var hawtioVersion, gatewayVersion string
+	if release != nil {
+		log.V(util.DebugLogLevel).Info(fmt.Sprintf("Using Hawtio Release: %s", release.Version))
+		hawtioVersion = release.OnlineImageTag
+		gatewayVersion = release.GatewayImageTag
+	} else {
+		hawtioVersion = buildVariables.GetOnlineVersion()
+		gatewayVersion = buildVariables.GetGatewayVersion()
+	}
+
 	log.V(util.DebugLogLevel).Info(fmt.Sprintf("Using Hawtio Image Version: %s", hawtioVersion))
 
 	hawtioContainer := newHawtioContainer(hawtio, apiSpec, openShiftConsoleURL, hawtioVersion, buildVariables.ImageRepository, log)
 
-	gatewayVersion := buildVariables.GetGatewayVersion()
 	log.V(util.DebugLogLevel).Info(fmt.Sprintf("Using Hawtio Gateway Image Version: %s", gatewayVersion))
 
 	gatewayContainer := newGatewayContainer(hawtio, apiSpec, gatewayVersion, buildVariables.GatewayImageRepository, log)
 
+	if release != nil {
+		release.filterEnvVars(&hawtioContainer, log)
+		release.filterEnvVars(&gatewayContainer, log)
+	}
+
 	annotations := map[string]string{
 		configVersionAnnotation: configMapVersion,
 	}
@@ -114,7 +163,7 @@ func newPodTemplateSpec(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServer
 	}
 	PropagateAnnotations(hawtio, annotations, log)
 
-	volumeMounts, err := newVolumeMounts(hawtio, apiSpec, hawtioVersion, hawtio.Spec.RBAC.ConfigMap, buildVariables, log)
+	volumeMounts, err := newVolumeMounts(hawtio, apiSpec, release, hawtioVersion, hawtio.Spec.RBAC.ConfigMap, buildVariables, log)
 	if err != nil {
 		return corev1.PodTemplateSpec{}, err
 	}
@@ -151,6 +200,14 @@ func newPodTemplateSpec(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServer
 	}
 	volumes := newVolumes(hawtio, apiSpec, log)
 
+	writableVolumes, onlineMounts, gatewayMounts := newWritableVolumes(hawtio)
+	volumes = append(volumes, writableVolumes...)
+	hawtioContainer.VolumeMounts = append(hawtioContainer.VolumeMounts, onlineMounts...)
+	gatewayContainer.VolumeMounts = append(gatewayContainer.VolumeMounts, gatewayMounts...)
+
+	hawtioContainer.SecurityContext = newContainerSecurityContext(hawtio)
+	gatewayContainer.SecurityContext = newContainerSecurityContext(hawtio)
+
 	labels := LabelsForHawtio(hawtio.Name)
 	additionalLabels, err := labelUtils.ConvertSelectorToLabelsMap(buildVariables.AdditionalLabels)
 	if err != nil {
@@ -173,16 +230,55 @@ func newPodTemplateSpec(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServer
 				hawtioContainer,
 				gatewayContainer,
 			},
-			ServiceAccountName: hawtio.Name,
-			Volumes:            volumes,
+			ServiceAccountName:        hawtio.Name,
+			ImagePullSecrets:          hawtio.Spec.Images.PullSecrets,
+			Volumes:                   volumes,
+			NodeSelector:              hawtio.Spec.NodeSelector,
+			Tolerations:               hawtio.Spec.Tolerations,
+			Affinity:                  newAffinity(hawtio),
+			TopologySpreadConstraints: hawtio.Spec.TopologySpreadConstraints,
+			PriorityClassName:         hawtio.Spec.PriorityClassName,
+			RuntimeClassName:          hawtio.Spec.RuntimeClassName,
+			SecurityContext:           newPodSecurityContext(hawtio, apiSpec),
 		},
 	}
 
+	applyPodTemplate(hawtio, &pod.Spec, &pod.Spec.Containers[0], &pod.Spec.Containers[1], log)
+
 	log.V(util.DebugLogLevel).Info(fmt.Sprintf("PodTemplateSpec: %s", util.JSONToString(pod)))
 
 	return pod, err
 }
 
+// newAffinity returns the affinity specified in the Hawtio CR, or, if more than
+// one replica is requested or may be autoscaled to, prefers scheduling the pods on different nodes,
+// so that the console remains available when a node goes down.
+func newAffinity(hawtio *hawtiov2.Hawtio) *corev1.Affinity {
+	if hawtio.Spec.Affinity != nil {
+		return hawtio.Spec.Affinity
+	}
+
+	if !isMultiReplica(hawtio) {
+		return nil
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: LabelsForHawtio(hawtio.Name),
+						},
+						TopologyKey: corev1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
 func newVolumes(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, log logr.Logger) []corev1.Volume {
 	log.V(util.DebugLogLevel).Info("Creating new volumes")
 
@@ -213,7 +309,7 @@ func newVolumes(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, lo
 	return volumes
 }
 
-func newVolumeMounts(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, hawtioVersion string, rbacConfigMapName string, buildVariables util.BuildVariables, log logr.Logger) (map[string]corev1.VolumeMount, error) {
+func newVolumeMounts(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, release *OperandRelease, hawtioVersion string, rbacConfigMapName string, buildVariables util.BuildVariables, log logr.Logger) (map[string]corev1.VolumeMount, error) {
 	var volumeMounts map[string]corev1.VolumeMount
 	var volumeMountPath string
 
@@ -236,7 +332,7 @@ func newVolumeMounts(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpe
 	 * The serving-certificate volume
 	 */
 	if util.IsSSL(hawtio, apiSpec) {
-		volumeMountPath, err := getServingCertificateMountPath(hawtioVersion, buildVariables.LegacyServingCertificateMountVersion)
+		volumeMountPath, err := servingCertificateMountPath(release, hawtioVersion, buildVariables.LegacyServingCertificateMountVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -267,6 +363,15 @@ func newVolumeMounts(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpe
 	return volumeMounts, nil
 }
 
+// servingCertificateMountPath returns the mount path of the serving certificate
+// declared by the operand release, or else for the version of the configured image.
+func servingCertificateMountPath(release *OperandRelease, version string, legacyServingCertificateMountVersion string) (string, error) {
+	if release != nil {
+		return release.ServingCertificateMountPath, nil
+	}
+	return getServingCertificateMountPath(version, legacyServingCertificateMountVersion)
+}
+
 func getServingCertificateMountPath(version string, legacyServingCertificateMountVersion string) (string, error) {
 	if len(version) == 0 {
 		version = "latest"
@@ -5,13 +5,17 @@ import (
 
 	"github.com/go-logr/logr"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetServingCertificateMountPath(t *testing.T) {
@@ -313,3 +317,615 @@ func TestNewDeploymentMasterBurstSize(t *testing.T) {
 		})
 	}
 }
+
+func TestNewDeploymentScheduling(t *testing.T) {
+	apiSpec := &capabilities.ApiServerSpec{
+		IsOpenShift4: true,
+	}
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "2.3.0",
+		GatewayImageVersion:    "2.3.0",
+	}
+	log := logr.Discard()
+
+	one := int32(1)
+	two := int32(2)
+	runtimeClassName := "kata"
+
+	infraTolerations := []corev1.Toleration{
+		{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}
+	nodeAffinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists},
+						},
+					},
+				},
+			},
+		},
+	}
+	spreadConstraints := []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       corev1.LabelTopologyZone,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		},
+	}
+
+	t.Run("Single replica", func(t *testing.T) {
+		hawtio := &hawtiov2.Hawtio{
+			ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+			Spec:       hawtiov2.HawtioSpec{Replicas: &one},
+		}
+
+		deployment, err := NewDeployment(hawtio, apiSpec, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		podSpec := deployment.Spec.Template.Spec
+		assert.Nil(t, podSpec.Affinity)
+		assert.Empty(t, podSpec.NodeSelector)
+		assert.Empty(t, podSpec.Tolerations)
+	})
+
+	t.Run("Multiple replicas default anti-affinity", func(t *testing.T) {
+		hawtio := &hawtiov2.Hawtio{
+			ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+			Spec:       hawtiov2.HawtioSpec{Replicas: &two},
+		}
+
+		deployment, err := NewDeployment(hawtio, apiSpec, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		affinity := deployment.Spec.Template.Spec.Affinity
+		if assert.NotNil(t, affinity) && assert.NotNil(t, affinity.PodAntiAffinity) {
+			terms := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			assert.Len(t, terms, 1)
+			assert.Equal(t, corev1.LabelHostname, terms[0].PodAffinityTerm.TopologyKey)
+			assert.Equal(t, LabelsForHawtio("hawtio-online"), terms[0].PodAffinityTerm.LabelSelector.MatchLabels)
+		}
+	})
+
+	t.Run("Explicit scheduling", func(t *testing.T) {
+		hawtio := &hawtiov2.Hawtio{
+			ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+			Spec: hawtiov2.HawtioSpec{
+				Replicas:                  &two,
+				NodeSelector:              map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:               infraTolerations,
+				Affinity:                  nodeAffinity,
+				TopologySpreadConstraints: spreadConstraints,
+				PriorityClassName:         "system-cluster-critical",
+				RuntimeClassName:          &runtimeClassName,
+			},
+		}
+
+		deployment, err := NewDeployment(hawtio, apiSpec, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		podSpec := deployment.Spec.Template.Spec
+		assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, podSpec.NodeSelector)
+		assert.Equal(t, infraTolerations, podSpec.Tolerations)
+		// The explicit affinity replaces the default anti-affinity
+		assert.Equal(t, nodeAffinity, podSpec.Affinity)
+		assert.Equal(t, spreadConstraints, podSpec.TopologySpreadConstraints)
+		assert.Equal(t, "system-cluster-critical", podSpec.PriorityClassName)
+		assert.Equal(t, &runtimeClassName, podSpec.RuntimeClassName)
+	})
+}
+
+func TestNewDeploymentContainerResources(t *testing.T) {
+	apiSpec := &capabilities.ApiServerSpec{
+		IsOpenShift4: true,
+	}
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "2.3.0",
+		GatewayImageVersion:    "2.3.0",
+	}
+	log := logr.Discard()
+
+	shared := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("200Mi")},
+	}
+	online := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+	}
+	gateway := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("500Mi")},
+	}
+
+	testCases := []struct {
+		name               string
+		containerResources hawtiov2.HawtioContainerResources
+		expectedOnline     corev1.ResourceRequirements
+		expectedGateway    corev1.ResourceRequirements
+	}{
+		{
+			"Shared resources",
+			hawtiov2.HawtioContainerResources{},
+			shared,
+			shared,
+		},
+		{
+			"Online resources",
+			hawtiov2.HawtioContainerResources{Online: &online},
+			online,
+			shared,
+		},
+		{
+			"Online and gateway resources",
+			hawtiov2.HawtioContainerResources{Online: &online, Gateway: &gateway},
+			online,
+			gateway,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hawtio := &hawtiov2.Hawtio{
+				ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+				Spec: hawtiov2.HawtioSpec{
+					Resources:          shared,
+					ContainerResources: tc.containerResources,
+				},
+			}
+
+			deployment, err := NewDeployment(hawtio, apiSpec, "", "", "", buildVariables, log)
+			assert.NoError(t, err)
+
+			containers := deployment.Spec.Template.Spec.Containers
+			assert.Equal(t, tc.expectedOnline, containers[0].Resources)
+			assert.Equal(t, tc.expectedGateway, containers[1].Resources)
+		})
+	}
+}
+
+func TestNewDeploymentSecurityContext(t *testing.T) {
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "2.3.0",
+		GatewayImageVersion:    "2.3.0",
+	}
+	log := logr.Discard()
+
+	newHawtio := func() *hawtiov2.Hawtio {
+		return &hawtiov2.Hawtio{
+			ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+		}
+	}
+
+	t.Run("Kubernetes defaults", func(t *testing.T) {
+		deployment, err := NewDeployment(newHawtio(), &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		podSpec := deployment.Spec.Template.Spec
+		if assert.NotNil(t, podSpec.SecurityContext) {
+			assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+			assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+			assert.Equal(t, defaultRunAsUser, *podSpec.SecurityContext.RunAsUser)
+		}
+
+		for _, container := range podSpec.Containers {
+			if assert.NotNil(t, container.SecurityContext) {
+				assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+				assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+				assert.Nil(t, container.SecurityContext.ReadOnlyRootFilesystem)
+			}
+		}
+	})
+
+	t.Run("OpenShift defaults", func(t *testing.T) {
+		apiSpec := &capabilities.ApiServerSpec{IsOpenShift4: true}
+		deployment, err := NewDeployment(newHawtio(), apiSpec, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		// The user is assigned by the SCCs
+		securityContext := deployment.Spec.Template.Spec.SecurityContext
+		if assert.NotNil(t, securityContext) {
+			assert.True(t, *securityContext.RunAsNonRoot)
+			assert.Nil(t, securityContext.RunAsUser)
+		}
+	})
+
+	t.Run("Read-only root filesystem", func(t *testing.T) {
+		hawtio := newHawtio()
+		hawtio.Spec.SecurityContext.ReadOnlyRootFilesystem = true
+
+		deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		podSpec := deployment.Spec.Template.Spec
+		volumes := map[string]corev1.Volume{}
+		for _, volume := range podSpec.Volumes {
+			volumes[volume.Name] = volume
+		}
+
+		for _, container := range podSpec.Containers {
+			assert.True(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+
+			mounts := map[string]string{}
+			for _, mount := range container.VolumeMounts {
+				mounts[mount.MountPath] = mount.Name
+			}
+			assert.Contains(t, mounts, tmpVolumeMountPath)
+			assert.NotNil(t, volumes[mounts[tmpVolumeMountPath]].EmptyDir)
+		}
+
+		onlineMounts := map[string]string{}
+		for _, mount := range podSpec.Containers[0].VolumeMounts {
+			onlineMounts[mount.MountPath] = mount.Name
+		}
+		assert.Contains(t, onlineMounts, nginxCacheMountPath)
+		assert.Contains(t, onlineMounts, nginxRunMountPath)
+	})
+
+	t.Run("Custom security contexts", func(t *testing.T) {
+		runAsUser := int64(2000)
+		privileged := false
+
+		hawtio := newHawtio()
+		hawtio.Spec.SecurityContext = hawtiov2.HawtioSecurityContext{
+			Pod:                    &corev1.PodSecurityContext{RunAsUser: &runAsUser},
+			Container:              &corev1.SecurityContext{Privileged: &privileged},
+			ReadOnlyRootFilesystem: true,
+		}
+
+		deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+		assert.NoError(t, err)
+
+		podSpec := deployment.Spec.Template.Spec
+		assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &runAsUser}, podSpec.SecurityContext)
+
+		containerContext := podSpec.Containers[0].SecurityContext
+		assert.False(t, *containerContext.Privileged)
+		assert.Nil(t, containerContext.AllowPrivilegeEscalation)
+		assert.True(t, *containerContext.ReadOnlyRootFilesystem)
+		// The CR is left unchanged
+		assert.Nil(t, hawtio.Spec.SecurityContext.Container.ReadOnlyRootFilesystem)
+	})
+}
+
+func TestNewDeploymentPodTemplate(t *testing.T) {
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "2.3.0",
+		GatewayImageVersion:    "2.3.0",
+	}
+	log := logr.Discard()
+
+	hawtio := &hawtiov2.Hawtio{
+		ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+		Spec: hawtiov2.HawtioSpec{
+			Type: hawtiov2.NamespaceHawtioDeploymentType,
+			PodTemplate: hawtiov2.HawtioPodTemplate{
+				Online: hawtiov2.HawtioContainerTemplate{
+					Env: []corev1.EnvVar{
+						{Name: "EXTRA", Value: "extra"},
+						// Managed by the operator
+						{Name: HawtioTypeEnvVar, Value: "cluster"},
+					},
+					EnvFrom: []corev1.EnvFromSource{
+						{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "extra-env"}}},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "plugins", MountPath: "/plugins"},
+					},
+				},
+				Gateway: hawtiov2.HawtioContainerTemplate{
+					VolumeMounts: []corev1.VolumeMount{
+						// Managed by the operator
+						{Name: "plugins", MountPath: serviceSigningSecretVolumeMountPath},
+					},
+				},
+				Volumes: []corev1.Volume{
+					newEmptyDirVolume("plugins"),
+					// Managed by the operator
+					newEmptyDirVolume(serviceSigningSecretVolumeName),
+				},
+				InitContainers: []corev1.Container{{Name: "install-plugins"}},
+				Containers: []corev1.Container{
+					{Name: "log-shipper"},
+					// Managed by the operator
+					{Name: "hawtio-online-gateway-container"},
+				},
+			},
+		},
+	}
+
+	deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+
+	podSpec := deployment.Spec.Template.Spec
+
+	online := podSpec.Containers[0]
+	assert.Contains(t, online.Env, corev1.EnvVar{Name: "EXTRA", Value: "extra"})
+	assert.Contains(t, online.Env, corev1.EnvVar{Name: HawtioTypeEnvVar, Value: "namespace"})
+	assert.NotContains(t, online.Env, corev1.EnvVar{Name: HawtioTypeEnvVar, Value: "cluster"})
+	assert.Equal(t, hawtio.Spec.PodTemplate.Online.EnvFrom, online.EnvFrom)
+	assert.Contains(t, online.VolumeMounts, corev1.VolumeMount{Name: "plugins", MountPath: "/plugins"})
+
+	gateway := podSpec.Containers[1]
+	assert.Contains(t, gateway.VolumeMounts, newVolumeMount(serviceSigningSecretVolumeName, serviceSigningSecretVolumeMountPath, ""))
+	assert.NotContains(t, gateway.VolumeMounts, corev1.VolumeMount{Name: "plugins", MountPath: serviceSigningSecretVolumeMountPath})
+
+	volumes := map[string]corev1.Volume{}
+	for _, volume := range podSpec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	assert.Contains(t, volumes, "plugins")
+	assert.NotNil(t, volumes[serviceSigningSecretVolumeName].Secret)
+
+	assert.Equal(t, []corev1.Container{{Name: "install-plugins"}}, podSpec.InitContainers)
+	if assert.Len(t, podSpec.Containers, 3) {
+		assert.Equal(t, "log-shipper", podSpec.Containers[2].Name)
+		assert.NotEmpty(t, podSpec.Containers[1].Image)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"environment variable HAWTIO_ONLINE_MODE of container hawtio-online-container",
+		"volume mount /etc/tls/private/serving of container hawtio-online-gateway-container",
+		"volume hawtio-online-tls-serving",
+		"container hawtio-online-gateway-container",
+	}, PodTemplateConflicts(hawtio, &podSpec))
+}
+
+func TestNewDeploymentImages(t *testing.T) {
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "2.3.0",
+		GatewayImageVersion:    "2.3.0",
+	}
+	log := logr.Discard()
+
+	tests := []struct {
+		name                 string
+		images               hawtiov2.HawtioImages
+		expectedOnline       string
+		expectedGateway      string
+		expectedOnlinePolicy corev1.PullPolicy
+	}{
+		{
+			name:                 "operator images",
+			expectedOnline:       "quay.io/hawtio/online:2.3.0",
+			expectedGateway:      "quay.io/hawtio/online-gateway:2.3.0",
+			expectedOnlinePolicy: corev1.PullAlways,
+		},
+		{
+			name: "overridden images",
+			images: hawtiov2.HawtioImages{
+				Online: hawtiov2.HawtioImage{
+					Repository: "registry.example.com/hawtio/online",
+					Tag:        "2.4.0",
+					PullPolicy: corev1.PullIfNotPresent,
+				},
+				Gateway: hawtiov2.HawtioImage{
+					Digest: "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+				},
+				PullSecrets: []corev1.LocalObjectReference{{Name: "registry-credentials"}},
+			},
+			expectedOnline:       "registry.example.com/hawtio/online:2.4.0",
+			expectedGateway:      "quay.io/hawtio/online-gateway@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+			expectedOnlinePolicy: corev1.PullIfNotPresent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hawtio := &hawtiov2.Hawtio{
+				ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+				Spec:       hawtiov2.HawtioSpec{Images: tt.images},
+			}
+
+			deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+			assert.NoError(t, err)
+
+			podSpec := deployment.Spec.Template.Spec
+			assert.Equal(t, tt.expectedOnline, podSpec.Containers[0].Image)
+			assert.Equal(t, tt.expectedOnlinePolicy, podSpec.Containers[0].ImagePullPolicy)
+			assert.Equal(t, tt.expectedGateway, podSpec.Containers[1].Image)
+			assert.Equal(t, tt.images.PullSecrets, podSpec.ImagePullSecrets)
+		})
+	}
+}
+
+func TestNewDeploymentVersion(t *testing.T) {
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+		ImageVersion:           "3.0.0",
+		GatewayImageVersion:    "3.0.0",
+	}
+	log := logr.Discard()
+
+	newHawtio := func(version string) *hawtiov2.Hawtio {
+		return &hawtiov2.Hawtio{
+			ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+			Spec: hawtiov2.HawtioSpec{
+				Type:    hawtiov2.NamespaceHawtioDeploymentType,
+				Version: version,
+				Nginx:   hawtiov2.HawtioNginx{MasterBurstSize: "100"},
+			},
+		}
+	}
+
+	// The images and environment of the selected release
+	deployment, err := NewDeployment(newHawtio("2.2"), &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Equal(t, "quay.io/hawtio/online:2.2.0", containers[0].Image)
+	assert.Equal(t, "quay.io/hawtio/online-gateway:2.2.0", containers[1].Image)
+
+	_, ok := findEnvVar(containers[0].Env, NginxMasterBurstSizeEnvVar)
+	assert.False(t, ok)
+	_, ok = findEnvVar(containers[0].Env, HawtioOnlineLogLvlEnvVar)
+	assert.False(t, ok)
+	_, ok = findEnvVar(containers[1].Env, GatewayMaskIPEnvVar)
+	assert.False(t, ok)
+	_, ok = findEnvVar(containers[0].Env, HawtioTypeEnvVar)
+	assert.True(t, ok)
+
+	// The images of the images field take precedence
+	hawtio := newHawtio("2.2")
+	hawtio.Spec.Images.Online.Tag = "2.2.1"
+	deployment, err = NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/hawtio/online:2.2.1", deployment.Spec.Template.Spec.Containers[0].Image)
+
+	// The release the operator is built for
+	deployment, err = NewDeployment(newHawtio(""), &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+
+	containers = deployment.Spec.Template.Spec.Containers
+	assert.Equal(t, "quay.io/hawtio/online:3.0.0", containers[0].Image)
+	value, ok := findEnvVar(containers[0].Env, NginxMasterBurstSizeEnvVar)
+	assert.True(t, ok)
+	assert.Equal(t, "100", value)
+
+	// An unsupported version falls back to the release the operator is built for
+	deployment, err = NewDeployment(newHawtio("1.0"), &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/hawtio/online:3.0.0", deployment.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestNewDeploymentProbes(t *testing.T) {
+	buildVariables := util.BuildVariables{
+		ImageRepository:        "quay.io/hawtio/online",
+		GatewayImageRepository: "quay.io/hawtio/online-gateway",
+	}
+	log := logr.Discard()
+
+	period := int32(20)
+	timeout := int32(5)
+	failureThreshold := int32(60)
+
+	hawtio := &hawtiov2.Hawtio{
+		ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+		Spec: hawtiov2.HawtioSpec{
+			HealthChecks: hawtiov2.HawtioHealthCheckPeriods{
+				OnlineReadinessPeriod: &period,
+				GatewayLivenessPeriod: &period,
+			},
+		},
+	}
+
+	// The defaults and the health check periods
+	deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+
+	online := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, int32(5), online.ReadinessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(1), online.ReadinessProbe.TimeoutSeconds)
+	assert.Equal(t, period, online.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(OnlineLivenessPeriodValue), online.LivenessProbe.PeriodSeconds)
+	assert.Nil(t, online.StartupProbe)
+
+	gateway := deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, period, gateway.LivenessProbe.PeriodSeconds)
+	assert.Nil(t, gateway.StartupProbe)
+
+	// The probe settings take precedence
+	hawtio.Spec.Probes = hawtiov2.HawtioProbes{
+		Online: hawtiov2.HawtioContainerProbes{
+			Readiness: hawtiov2.HawtioProbe{PeriodSeconds: &timeout, TimeoutSeconds: &timeout},
+		},
+		Gateway: hawtiov2.HawtioContainerProbes{
+			Liveness: hawtiov2.HawtioProbe{FailureThreshold: &failureThreshold},
+			Startup:  &hawtiov2.HawtioProbe{},
+		},
+	}
+
+	deployment, err = NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", buildVariables, log)
+	assert.NoError(t, err)
+
+	online = deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, timeout, online.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, timeout, online.ReadinessProbe.TimeoutSeconds)
+	assert.Equal(t, int32(5), online.ReadinessProbe.InitialDelaySeconds)
+	assert.Nil(t, online.StartupProbe)
+
+	gateway = deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, failureThreshold, gateway.LivenessProbe.FailureThreshold)
+	assert.Equal(t, period, gateway.LivenessProbe.PeriodSeconds)
+	require.NotNil(t, gateway.StartupProbe)
+	assert.Equal(t, int32(StartupPeriodValue), gateway.StartupProbe.PeriodSeconds)
+	assert.Equal(t, int32(StartupFailureThresholdValue), gateway.StartupProbe.FailureThreshold)
+	assert.Equal(t, "/status", gateway.StartupProbe.HTTPGet.Path)
+}
+
+func TestNewDeploymentRollout(t *testing.T) {
+	maxSurge := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromInt32(0)
+	deadline := int32(300)
+	revisions := int32(3)
+
+	tests := []struct {
+		name             string
+		rollout          hawtiov2.HawtioRollout
+		expectedStrategy appsv1.DeploymentStrategy
+	}{
+		{
+			name: "default",
+			expectedStrategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+		},
+		{
+			name: "recreate",
+			rollout: hawtiov2.HawtioRollout{
+				Strategy: appsv1.RecreateDeploymentStrategyType,
+			},
+			expectedStrategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+		{
+			name: "rolling update",
+			rollout: hawtiov2.HawtioRollout{
+				MaxSurge:                &maxSurge,
+				MaxUnavailable:          &maxUnavailable,
+				MinReadySeconds:         10,
+				ProgressDeadlineSeconds: &deadline,
+				RevisionHistoryLimit:    &revisions,
+			},
+			expectedStrategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hawtio := &hawtiov2.Hawtio{
+				ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online", Namespace: "hawtio"},
+				Spec:       hawtiov2.HawtioSpec{Rollout: tt.rollout},
+			}
+
+			deployment, err := NewDeployment(hawtio, &capabilities.ApiServerSpec{}, "", "", "", util.BuildVariables{}, logr.Discard())
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expectedStrategy, deployment.Spec.Strategy)
+			assert.Equal(t, tt.rollout.MinReadySeconds, deployment.Spec.MinReadySeconds)
+			assert.Equal(t, tt.rollout.ProgressDeadlineSeconds, deployment.Spec.ProgressDeadlineSeconds)
+			assert.Equal(t, tt.rollout.RevisionHistoryLimit, deployment.Spec.RevisionHistoryLimit)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+const defaultTargetCPUUtilizationPercentage int32 = 80
+
+func NewDefaultHorizontalPodAutoscaler(hawtio *hawtiov2.Hawtio) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// IsAutoscaled returns whether the replicas of the Hawtio CR are managed by a HorizontalPodAutoscaler
+func IsAutoscaled(hawtio *hawtiov2.Hawtio) bool {
+	return hawtio.Spec.Autoscaling.Enabled
+}
+
+// DesiredReplicas returns the number of replicas of the Hawtio deployment.
+// When autoscaled, the replicas field is set by the HorizontalPodAutoscaler
+// and is kept within the autoscaling limits, eg. until the autoscaler has
+// first scaled the Hawtio CR, or if the field is reset by the user.
+func DesiredReplicas(hawtio *hawtiov2.Hawtio) int32 {
+	replicas := pointer.Int32PtrDerefOr(hawtio.Spec.Replicas, 1)
+	if !IsAutoscaled(hawtio) {
+		return replicas
+	}
+
+	autoscaling := hawtio.Spec.Autoscaling
+	replicas = max(replicas, pointer.Int32PtrDerefOr(autoscaling.MinReplicas, 1))
+	if autoscaling.MaxReplicas > 0 {
+		replicas = min(replicas, autoscaling.MaxReplicas)
+	}
+	return replicas
+}
+
+// isMultiReplica returns whether the Hawtio deployment has, or may be scaled
+// to, more than one replica. When autoscaled, it does not depend on the current
+// number of replicas, so that scaling does not trigger a rollout of the pods.
+func isMultiReplica(hawtio *hawtiov2.Hawtio) bool {
+	if IsAutoscaled(hawtio) {
+		return hawtio.Spec.Autoscaling.MaxReplicas > 1
+	}
+	return DesiredReplicas(hawtio) > 1
+}
+
+func NewHorizontalPodAutoscaler(hawtio *hawtiov2.Hawtio, log logr.Logger) *autoscalingv2.HorizontalPodAutoscaler {
+	log.V(util.DebugLogLevel).Info("Reconciling horizontal pod autoscaler")
+
+	annotations := map[string]string{}
+	PropagateAnnotations(hawtio, annotations, log)
+
+	labels := map[string]string{
+		LabelAppKey: "hawtio",
+	}
+	PropagateLabels(hawtio, labels, log)
+
+	autoscaling := hawtio.Spec.Autoscaling
+	minReplicas := pointer.Int32PtrDerefOr(autoscaling.MinReplicas, 1)
+
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		metrics = append(metrics, newResourceMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilizationPercentage))
+	}
+	if autoscaling.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, newResourceMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilizationPercentage))
+	}
+	if len(metrics) == 0 {
+		metrics = append(metrics, newResourceMetric(corev1.ResourceCPU, defaultTargetCPUUtilizationPercentage))
+	}
+
+	hpa := NewDefaultHorizontalPodAutoscaler(hawtio)
+	hpa.SetLabels(labels)
+	hpa.SetAnnotations(annotations)
+	hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+		// Scale the Hawtio CR, rather than the deployment, so that the
+		// replicas set by the autoscaler are reconciled by the operator
+		ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+			APIVersion: hawtiov2.SchemeGroupVersion.String(),
+			Kind:       "Hawtio",
+			Name:       hawtio.Name,
+		},
+		MinReplicas: &minReplicas,
+		MaxReplicas: max(autoscaling.MaxReplicas, minReplicas),
+		Metrics:     metrics,
+	}
+
+	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New horizontal pod autoscaler %s", util.JSONToString(hpa)))
+	return hpa
+}
+
+func newResourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
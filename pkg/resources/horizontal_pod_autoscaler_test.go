@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+func newAutoscaledHawtio(replicas *int32, autoscaling hawtiov2.HawtioAutoscaling) *hawtiov2.Hawtio {
+	return &hawtiov2.Hawtio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hawtio-online",
+			Namespace: "hawtio",
+		},
+		Spec: hawtiov2.HawtioSpec{
+			Replicas:    replicas,
+			Autoscaling: autoscaling,
+		},
+	}
+}
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	minReplicas := int32(2)
+	memory := int32(70)
+
+	tests := []struct {
+		name                string
+		autoscaling         hawtiov2.HawtioAutoscaling
+		expectedMinReplicas int32
+		expectedMetrics     []corev1.ResourceName
+	}{
+		{
+			name:                "default",
+			autoscaling:         hawtiov2.HawtioAutoscaling{Enabled: true, MaxReplicas: 3},
+			expectedMinReplicas: 1,
+			expectedMetrics:     []corev1.ResourceName{corev1.ResourceCPU},
+		},
+		{
+			name: "memory target",
+			autoscaling: hawtiov2.HawtioAutoscaling{
+				Enabled:                           true,
+				MinReplicas:                       &minReplicas,
+				MaxReplicas:                       5,
+				TargetMemoryUtilizationPercentage: &memory,
+			},
+			expectedMinReplicas: 2,
+			expectedMetrics:     []corev1.ResourceName{corev1.ResourceMemory},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hawtio := newAutoscaledHawtio(nil, tt.autoscaling)
+
+			hpa := NewHorizontalPodAutoscaler(hawtio, logr.Discard())
+
+			assert.Equal(t, autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "hawt.io/v2",
+				Kind:       "Hawtio",
+				Name:       "hawtio-online",
+			}, hpa.Spec.ScaleTargetRef)
+			assert.Equal(t, tt.expectedMinReplicas, *hpa.Spec.MinReplicas)
+			assert.Equal(t, tt.autoscaling.MaxReplicas, hpa.Spec.MaxReplicas)
+
+			var metrics []corev1.ResourceName
+			for _, metric := range hpa.Spec.Metrics {
+				metrics = append(metrics, metric.Resource.Name)
+			}
+			assert.Equal(t, tt.expectedMetrics, metrics)
+		})
+	}
+}
+
+func TestDesiredReplicas(t *testing.T) {
+	minReplicas := int32(2)
+	autoscaling := hawtiov2.HawtioAutoscaling{Enabled: true, MinReplicas: &minReplicas, MaxReplicas: 4}
+
+	one, three, ten := int32(1), int32(3), int32(10)
+
+	assert.Equal(t, int32(1), DesiredReplicas(newAutoscaledHawtio(nil, hawtiov2.HawtioAutoscaling{})))
+	assert.Equal(t, int32(10), DesiredReplicas(newAutoscaledHawtio(&ten, hawtiov2.HawtioAutoscaling{})))
+
+	// The replicas set by the autoscaler are kept within its limits
+	assert.Equal(t, int32(2), DesiredReplicas(newAutoscaledHawtio(nil, autoscaling)))
+	assert.Equal(t, int32(2), DesiredReplicas(newAutoscaledHawtio(&one, autoscaling)))
+	assert.Equal(t, int32(3), DesiredReplicas(newAutoscaledHawtio(&three, autoscaling)))
+	assert.Equal(t, int32(4), DesiredReplicas(newAutoscaledHawtio(&ten, autoscaling)))
+}
@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+// BackendCACertificateKey is the key of the CA bundle in the ConfigMap
+// referenced by the BackendTLSPolicy
+const BackendCACertificateKey = "ca.crt"
+
+// IsBackendTLSPolicyApplicable returns whether the Gateway must re-encrypt the traffic
+// to the Hawtio service, that is when it is exposed with an HTTPRoute and uses SSL
+func IsBackendTLSPolicyApplicable(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
+	return apiSpec.BackendTLSPolicy && IsHTTPRouteApplicable(hawtio, apiSpec) && util.IsSSL(hawtio, apiSpec)
+}
+
+func NewDefaultBackendTLSPolicy(hawtio *hawtiov2.Hawtio) *gatewayv1.BackendTLSPolicy {
+	return &gatewayv1.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// NewBackendTLSPolicy create a new BackendTLSPolicy resource, so that the Gateway
+// validates the serving certificate of the Hawtio service for the given host name
+func NewBackendTLSPolicy(hawtio *hawtiov2.Hawtio, hostname string, log logr.Logger) *gatewayv1.BackendTLSPolicy {
+	log.V(util.DebugLogLevel).Info("Reconciling BackendTLSPolicy")
+
+	annotations := map[string]string{}
+	resources.PropagateAnnotations(hawtio, annotations, log)
+
+	labels := resources.LabelsForHawtio(hawtio.Name)
+	resources.PropagateLabels(hawtio, labels, log)
+
+	policy := NewDefaultBackendTLSPolicy(hawtio)
+	policy.SetLabels(labels)
+	policy.SetAnnotations(annotations)
+	policy.Spec = gatewayv1.BackendTLSPolicySpec{
+		TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+			{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+					Group: "",
+					Kind:  "Service",
+					Name:  gatewayv1.ObjectName(hawtio.Name),
+				},
+			},
+		},
+		Validation: gatewayv1.BackendTLSPolicyValidation{
+			CACertificateRefs: []gatewayv1.LocalObjectReference{
+				{
+					Group: "",
+					Kind:  "ConfigMap",
+					Name:  gatewayv1.ObjectName(NewDefaultBackendCAConfigMap(hawtio).Name),
+				},
+			},
+			Hostname: gatewayv1.PreciseHostname(hostname),
+		},
+	}
+
+	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New BackendTLSPolicy: %s", util.JSONToString(policy)))
+	return policy
+}
+
+func NewDefaultBackendCAConfigMap(hawtio *hawtiov2.Hawtio) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name + "-backend-ca",
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// NewBackendCAConfigMap create a new ConfigMap with the CA bundle the BackendTLSPolicy
+// validates the serving certificate of the Hawtio service against
+func NewBackendCAConfigMap(hawtio *hawtiov2.Hawtio, caBundle []byte, log logr.Logger) *corev1.ConfigMap {
+	annotations := map[string]string{}
+	resources.PropagateAnnotations(hawtio, annotations, log)
+
+	labels := resources.LabelsForHawtio(hawtio.Name)
+	resources.PropagateLabels(hawtio, labels, log)
+
+	configMap := NewDefaultBackendCAConfigMap(hawtio)
+	configMap.SetLabels(labels)
+	configMap.SetAnnotations(annotations)
+	configMap.Data = map[string]string{
+		BackendCACertificateKey: string(caBundle),
+	}
+
+	return configMap
+}
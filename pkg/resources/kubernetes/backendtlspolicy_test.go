@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackendTLSPolicy(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	hawtio.Spec.HTTPRoute.ParentRef.Name = "public"
+
+	apiSpec := &capabilities.ApiServerSpec{GatewayAPI: true, BackendTLSPolicy: true}
+	assert.True(t, IsBackendTLSPolicyApplicable(hawtio, apiSpec))
+
+	// No re-encryption for the plain service
+	internalSSL := false
+	plain := hawtio.DeepCopy()
+	plain.Spec.Auth.InternalSSL = &internalSSL
+	assert.False(t, IsBackendTLSPolicyApplicable(plain, apiSpec))
+
+	policy := NewBackendTLSPolicy(hawtio, "hawtio-online.hawtio.svc", logr.Discard())
+
+	assert.Equal(t, gatewayv1.Kind("Service"), policy.Spec.TargetRefs[0].Kind)
+	assert.Equal(t, gatewayv1.ObjectName(hawtio.Name), policy.Spec.TargetRefs[0].Name)
+	assert.Equal(t, []gatewayv1.LocalObjectReference{
+		{Kind: "ConfigMap", Name: "hawtio-online-backend-ca"},
+	}, policy.Spec.Validation.CACertificateRefs)
+	assert.Equal(t, gatewayv1.PreciseHostname("hawtio-online.hawtio.svc"), policy.Spec.Validation.Hostname)
+
+	configMap := NewBackendCAConfigMap(hawtio, []byte("-----BEGIN CERTIFICATE-----"), logr.Discard())
+	assert.Equal(t, "hawtio-online-backend-ca", configMap.Name)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", configMap.Data[BackendCACertificateKey])
+}
@@ -0,0 +1,196 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+// IsHTTPRouteApplicable returns whether the Hawtio service is exposed with an HTTPRoute,
+// that is when the Gateway API exposure is selected, a parent Gateway is referenced
+// and the cluster supports the Gateway API
+func IsHTTPRouteApplicable(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
+	return apiSpec.GatewayAPI && hawtio.Spec.HTTPRoute.ParentRef.Name != "" &&
+		util.GetExposureType(hawtio, apiSpec) == hawtiov2.GatewayAPIHawtioExposureType
+}
+
+func NewDefaultHTTPRoute(hawtio *hawtiov2.Hawtio) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// NewHTTPRoute create a new HTTPRoute resource
+func NewHTTPRoute(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, log logr.Logger) *gatewayv1.HTTPRoute {
+	log.V(util.DebugLogLevel).Info("Reconciling HTTPRoute")
+
+	servicePort := resources.PlainServicePort
+	if util.IsSSL(hawtio, apiSpec) {
+		servicePort = resources.SSLServicePort
+	}
+
+	annotations := map[string]string{}
+	resources.PropagateAnnotations(hawtio, annotations, log)
+
+	labels := resources.LabelsForHawtio(hawtio.Name)
+	resources.PropagateLabels(hawtio, labels, log)
+
+	parentRef := gatewayv1.ParentReference{
+		Name: gatewayv1.ObjectName(hawtio.Spec.HTTPRoute.ParentRef.Name),
+	}
+	if namespace := hawtio.Spec.HTTPRoute.ParentRef.Namespace; namespace != "" {
+		parentRef.Namespace = ptr.To(gatewayv1.Namespace(namespace))
+	}
+	if sectionName := hawtio.Spec.HTTPRoute.ParentRef.SectionName; sectionName != "" {
+		parentRef.SectionName = ptr.To(gatewayv1.SectionName(sectionName))
+	}
+
+	var hostnames []gatewayv1.Hostname
+	for _, hostname := range hawtio.Spec.HTTPRoute.Hostnames {
+		hostnames = append(hostnames, gatewayv1.Hostname(hostname))
+	}
+
+	httpRoute := NewDefaultHTTPRoute(hawtio)
+	httpRoute.SetLabels(labels)
+	httpRoute.SetAnnotations(annotations)
+	httpRoute.Spec = gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: []gatewayv1.ParentReference{parentRef},
+		},
+		Hostnames: hostnames,
+		Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Matches: []gatewayv1.HTTPRouteMatch{
+					{
+						Path: &gatewayv1.HTTPPathMatch{
+							Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+							Value: ptr.To("/"),
+						},
+					},
+				},
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: gatewayv1.ObjectName(hawtio.Name),
+								Port: ptr.To(gatewayv1.PortNumber(servicePort)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New HTTPRoute: %s", util.JSONToString(httpRoute)))
+	return httpRoute
+}
+
+// GetHTTPRouteURL determines the full URL of the given HTTPRoute.
+// The scheme, port and, unless the HTTPRoute has one, the host are those of
+// the listener of the parent Gateway, if known.
+func GetHTTPRouteURL(httpRoute *gatewayv1.HTTPRoute, gateway *gatewayv1.Gateway) string {
+	scheme := "http"
+	host := ""
+	port := 0
+
+	if listener := getGatewayListener(httpRoute, gateway); listener != nil {
+		if listener.Protocol == gatewayv1.HTTPSProtocolType {
+			scheme = "https"
+		}
+		if listener.Hostname != nil && !strings.HasPrefix(string(*listener.Hostname), "*") {
+			host = string(*listener.Hostname)
+		}
+		port = int(listener.Port)
+	}
+
+	for _, hostname := range httpRoute.Spec.Hostnames {
+		// Wildcard host names cannot be browsed
+		if !strings.HasPrefix(string(hostname), "*") {
+			host = string(hostname)
+			break
+		}
+	}
+
+	if host == "" && gateway != nil {
+		for _, address := range gateway.Status.Addresses {
+			if len(address.Value) > 0 {
+				host = address.Value
+				break
+			}
+		}
+	}
+
+	if host == "" {
+		host = "*" // host must be a wildcard
+	}
+
+	url := scheme + "://" + host
+	if port > 0 && !(scheme == "http" && port == 80) && !(scheme == "https" && port == 443) {
+		url = url + ":" + strconv.Itoa(port)
+	}
+
+	return url
+}
+
+// getGatewayListener returns the listener of the Gateway the HTTPRoute is attached to,
+// either the one of its section name, or the first HTTP or HTTPS listener
+func getGatewayListener(httpRoute *gatewayv1.HTTPRoute, gateway *gatewayv1.Gateway) *gatewayv1.Listener {
+	if gateway == nil {
+		return nil
+	}
+
+	var sectionName gatewayv1.SectionName
+	for _, parentRef := range httpRoute.Spec.ParentRefs {
+		if string(parentRef.Name) == gateway.Name && parentRef.SectionName != nil {
+			sectionName = *parentRef.SectionName
+		}
+	}
+
+	for i, listener := range gateway.Spec.Listeners {
+		if sectionName != "" && listener.Name != sectionName {
+			continue
+		}
+		if listener.Protocol == gatewayv1.HTTPProtocolType || listener.Protocol == gatewayv1.HTTPSProtocolType {
+			return &gateway.Spec.Listeners[i]
+		}
+	}
+
+	return nil
+}
+
+// GetHTTPRouteAcceptance reports whether the HTTPRoute has been accepted by at least
+// one of its parent Gateways. If not, the reason and message of the first rejection,
+// if any, are returned.
+func GetHTTPRouteAcceptance(httpRoute *gatewayv1.HTTPRoute) (bool, string, string) {
+	reason, message := "", ""
+	for _, parent := range httpRoute.Status.Parents {
+		condition := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if condition == nil {
+			continue
+		}
+		if condition.Status == metav1.ConditionTrue {
+			return true, "", ""
+		}
+		if reason == "" {
+			reason, message = condition.Reason, condition.Message
+		}
+	}
+
+	return false, reason, message
+}
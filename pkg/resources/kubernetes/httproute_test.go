@@ -0,0 +1,141 @@
+package kubernetes
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPRoute(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	hawtio.Spec.HTTPRoute = hawtiov2.HawtioHTTPRoute{
+		ParentRef: hawtiov2.HawtioGatewayReference{Name: "public", Namespace: "gateways", SectionName: "https"},
+		Hostnames: []string{"hawtio.example.com"},
+	}
+	internalSSL := false
+	hawtio.Spec.Auth.InternalSSL = &internalSSL
+
+	apiSpec := &capabilities.ApiServerSpec{GatewayAPI: true}
+	require.True(t, IsHTTPRouteApplicable(hawtio, apiSpec))
+	assert.False(t, IsHTTPRouteApplicable(hawtio, &capabilities.ApiServerSpec{}))
+
+	httpRoute := NewHTTPRoute(hawtio, apiSpec, logr.Discard())
+
+	assert.Equal(t, resources.LabelsForHawtio(hawtio.Name), httpRoute.Labels)
+	assert.Equal(t, []gatewayv1.ParentReference{
+		{
+			Name:        "public",
+			Namespace:   ptr.To(gatewayv1.Namespace("gateways")),
+			SectionName: ptr.To(gatewayv1.SectionName("https")),
+		},
+	}, httpRoute.Spec.ParentRefs)
+	assert.Equal(t, []gatewayv1.Hostname{"hawtio.example.com"}, httpRoute.Spec.Hostnames)
+	require.Len(t, httpRoute.Spec.Rules, 1)
+	backendRef := httpRoute.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName(hawtio.Name), backendRef.Name)
+	assert.Equal(t, gatewayv1.PortNumber(resources.PlainServicePort), *backendRef.Port)
+}
+
+func TestGetHTTPRouteURL(t *testing.T) {
+	wildcard := gatewayv1.Hostname("*.apps.example.com")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				{Name: "https", Hostname: &wildcard, Port: 8443, Protocol: gatewayv1.HTTPSProtocolType},
+			},
+		},
+		Status: gatewayv1.GatewayStatus{
+			Addresses: []gatewayv1.GatewayStatusAddress{{Value: "192.168.99.9"}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		httpRoute *gatewayv1.HTTPRoute
+		gateway   *gatewayv1.Gateway
+		expected  string
+	}{
+		{
+			name: "host name of the route and listener of the section",
+			httpRoute: &gatewayv1.HTTPRoute{
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "public", SectionName: ptr.To(gatewayv1.SectionName("https"))}},
+					},
+					Hostnames: []gatewayv1.Hostname{"*.example.com", "hawtio.apps.example.com"},
+				},
+			},
+			gateway:  gateway,
+			expected: "https://hawtio.apps.example.com:8443",
+		},
+		{
+			name: "address of the gateway",
+			httpRoute: &gatewayv1.HTTPRoute{
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: "public"}},
+					},
+				},
+			},
+			gateway:  gateway,
+			expected: "http://192.168.99.9",
+		},
+		{
+			name: "unknown gateway",
+			httpRoute: &gatewayv1.HTTPRoute{
+				Spec: gatewayv1.HTTPRouteSpec{
+					Hostnames: []gatewayv1.Hostname{"hawtio.example.com"},
+				},
+			},
+			expected: "http://hawtio.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetHTTPRouteURL(tc.httpRoute, tc.gateway))
+		})
+	}
+}
+
+func TestGetHTTPRouteAcceptance(t *testing.T) {
+	httpRoute := &gatewayv1.HTTPRoute{}
+	httpRoute.Status.Parents = []gatewayv1.RouteParentStatus{
+		{
+			ParentRef: gatewayv1.ParentReference{Name: "internal"},
+			Conditions: []metav1.Condition{
+				{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionFalse, Reason: "NotAllowedByListeners", Message: "Namespace not allowed"},
+			},
+		},
+	}
+
+	accepted, reason, message := GetHTTPRouteAcceptance(httpRoute)
+	assert.False(t, accepted)
+	assert.Equal(t, "NotAllowedByListeners", reason)
+	assert.Equal(t, "Namespace not allowed", message)
+
+	httpRoute.Status.Parents = append(httpRoute.Status.Parents, gatewayv1.RouteParentStatus{
+		ParentRef: gatewayv1.ParentReference{Name: "public"},
+		Conditions: []metav1.Condition{
+			{Type: string(gatewayv1.RouteConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"},
+		},
+	})
+
+	accepted, _, _ = GetHTTPRouteAcceptance(httpRoute)
+	assert.True(t, accepted)
+}
@@ -2,7 +2,9 @@ package kubernetes
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -16,6 +18,32 @@ import (
 	"github.com/hawtio/hawtio-operator/pkg/util"
 )
 
+const (
+	nginxIngressClassName = "nginx"
+	// nginxRewritePath captures the path of the requests the NGINX ingress
+	// controller forwards to the Hawtio service
+	nginxRewritePath = "/(.*)"
+	// NginxWhitelistSourceRangeAnnotation restricts the client addresses the
+	// NGINX ingress controller accepts
+	NginxWhitelistSourceRangeAnnotation = "nginx.ingress.kubernetes.io/whitelist-source-range"
+	nginxBackendProtocolAnnotation      = "nginx.ingress.kubernetes.io/backend-protocol"
+	nginxForceSSLRedirectAnnotation     = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+	nginxRewriteTargetAnnotation        = "nginx.ingress.kubernetes.io/rewrite-target"
+)
+
+// NginxAnnotations are the NGINX ingress controller annotations set by the operator
+var NginxAnnotations = []string{
+	nginxBackendProtocolAnnotation,
+	nginxForceSSLRedirectAnnotation,
+	nginxRewriteTargetAnnotation,
+	NginxWhitelistSourceRangeAnnotation,
+}
+
+// IsIngressApplicable returns whether the Hawtio service is exposed with an ingress
+func IsIngressApplicable(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
+	return util.GetExposureType(hawtio, apiSpec) == hawtiov2.IngressHawtioExposureType
+}
+
 func NewDefaultIngress(hawtio *hawtiov2.Hawtio) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
@@ -35,25 +63,80 @@ func NewIngress(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, se
 		servicePort = resources.SSLServicePort
 	}
 
+	spec := hawtio.Spec.Ingress
+	path := spec.Path
+	if path == "" {
+		path = "/"
+	}
+
 	annotations := map[string]string{}
 
-	if isSSL {
-		annotations["nginx.ingress.kubernetes.io/backend-protocol"] = "HTTPS"
-		annotations["nginx.ingress.kubernetes.io/force-ssl-redirect"] = "true"
+	if IsNginxIngress(hawtio) {
+		if isSSL {
+			annotations[nginxBackendProtocolAnnotation] = "HTTPS"
+			annotations[nginxForceSSLRedirectAnnotation] = "true"
+		}
+		// Serve the console at the root of the Hawtio service
+		annotations[nginxRewriteTargetAnnotation] = "/$1"
+		path = strings.TrimSuffix(path, "/") + nginxRewritePath
+		if ranges := hawtio.Spec.Access.AllowedSourceRanges; len(ranges) > 0 {
+			annotations[NginxWhitelistSourceRangeAnnotation] = strings.Join(ranges, ",")
+		}
+	} else if len(hawtio.Spec.Access.AllowedSourceRanges) > 0 {
+		log.Info("The allowed source ranges are not enforced by the ingress class, they must be configured with the ingress annotations", "IngressClass", spec.ClassName)
 	}
-	annotations["nginx.ingress.kubernetes.io/rewrite-target"] = "/$1"
 
 	resources.PropagateAnnotations(hawtio, annotations, log)
+	for key, value := range spec.Annotations {
+		annotations[key] = value
+	}
 
 	labels := resources.LabelsForHawtio(hawtio.Name)
 	resources.PropagateLabels(hawtio, labels, log)
 
-	ingressTLS := networkingv1.IngressTLS{}
-	if servingSecret != nil {
+	// The additional host names of the console are served along with those of the ingress
+	hosts := append(slices.Clone(spec.Hosts), hawtio.Spec.Hosts...)
+
+	ingressTLS := networkingv1.IngressTLS{
+		Hosts: hosts,
+	}
+	if spec.TLSSecret.Name != "" {
+		ingressTLS.SecretName = spec.TLSSecret.Name
+	} else if servingSecret != nil {
 		ingressTLS.SecretName = servingSecret.Name
 	}
 
 	pathPrefix := networkingv1.PathTypePrefix
+	ruleValue := networkingv1.IngressRuleValue{
+		HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{{
+				Path:     path,
+				PathType: &pathPrefix,
+				Backend: networkingv1.IngressBackend{
+					Service: &networkingv1.IngressServiceBackend{
+						Name: hawtio.Name,
+						Port: networkingv1.ServiceBackendPort{
+							Number: int32(servicePort),
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	var rules []networkingv1.IngressRule
+	if len(spec.Hosts) == 0 {
+		// Matches any host
+		rules = append(rules, networkingv1.IngressRule{
+			IngressRuleValue: ruleValue,
+		})
+	}
+	for _, host := range hosts {
+		rules = append(rules, networkingv1.IngressRule{
+			Host:             host,
+			IngressRuleValue: *ruleValue.DeepCopy(),
+		})
+	}
 
 	ingress := NewDefaultIngress(hawtio)
 	ingress.SetLabels(labels)
@@ -62,32 +145,23 @@ func NewIngress(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, se
 		TLS: []networkingv1.IngressTLS{
 			ingressTLS,
 		},
-		Rules: []networkingv1.IngressRule{
-			{
-				IngressRuleValue: networkingv1.IngressRuleValue{
-					HTTP: &networkingv1.HTTPIngressRuleValue{
-						Paths: []networkingv1.HTTPIngressPath{{
-							Path:     "/(.*)",
-							PathType: &pathPrefix,
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: hawtio.Name,
-									Port: networkingv1.ServiceBackendPort{
-										Number: int32(servicePort),
-									},
-								},
-							},
-						}},
-					},
-				},
-			},
-		},
+		Rules: rules,
+	}
+	if spec.ClassName != "" {
+		ingress.Spec.IngressClassName = &spec.ClassName
 	}
 
 	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New Ingress: %s", util.JSONToString(ingress)))
 	return ingress
 }
 
+// IsNginxIngress returns whether the ingress is assumed to be served by
+// the NGINX ingress controller, that is when no other class is specified
+func IsNginxIngress(hawtio *hawtiov2.Hawtio) bool {
+	className := hawtio.Spec.Ingress.ClassName
+	return className == "" || className == nginxIngressClassName
+}
+
 // GetIngressURL determines the full URL of the given ingress
 func GetIngressURL(ingress *networkingv1.Ingress) string {
 	var scheme string
@@ -108,16 +182,39 @@ func GetIngressURL(ingress *networkingv1.Ingress) string {
 	return url + path
 }
 
-func getIngressHostAndPort(ingress *networkingv1.Ingress) (string, string) {
-	ingressStatuses := ingress.Status.LoadBalancer.Ingress
-	if len(ingressStatuses) == 0 {
-		for _, ingressRule := range ingress.Spec.Rules {
-			if len(ingressRule.Host) > 0 {
-				return ingressRule.Host, ""
-			}
+// GetIngressURLs determines the URLs of the given ingress, that of its
+// address, or first host, followed by those of its other hosts
+func GetIngressURLs(ingress *networkingv1.Ingress) []string {
+	urls := []string{GetIngressURL(ingress)}
+
+	scheme := "http"
+	if len(ingress.Spec.TLS) > 0 {
+		scheme = "https"
+	}
+	path := getIngressPath(ingress)
+
+	for _, ingressRule := range ingress.Spec.Rules {
+		if len(ingressRule.Host) == 0 || strings.HasPrefix(ingressRule.Host, "*") {
+			continue
 		}
+		if url := scheme + "://" + ingressRule.Host + path; !slices.Contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
 
-		return "*", "" // host must be a wildcard
+	return urls
+}
+
+func getIngressHostAndPort(ingress *networkingv1.Ingress) (string, string) {
+	// The host the ingress is served at takes precedence over its address
+	for _, ingressRule := range ingress.Spec.Rules {
+		if len(ingressRule.Host) == 0 {
+			// The ingress matches any host, the additional host names aside
+			break
+		}
+		if !strings.HasPrefix(ingressRule.Host, "*") {
+			return ingressRule.Host, ""
+		}
 	}
 
 	// get host or ip of the first ingress status available
@@ -133,13 +230,14 @@ func getIngressHostAndPort(ingress *networkingv1.Ingress) (string, string) {
 		if len(host) > 0 {
 			for _, statusPort := range ingressStatus.Ports {
 				port = strconv.FormatInt(int64(statusPort.Port), 10)
-				continue // get the first port
+				break // get the first port
 			}
+			break
 		}
 	}
 
 	if len(host) == 0 {
-		return "*", port
+		return "*", port // host must be a wildcard
 	}
 
 	return host, port
@@ -149,7 +247,8 @@ func getIngressPath(ingress *networkingv1.Ingress) string {
 	for _, ingressRule := range ingress.Spec.Rules {
 		if ingressRule.IngressRuleValue.HTTP != nil {
 			for _, httpPath := range ingressRule.IngressRuleValue.HTTP.Paths {
-				return httpPath.Path
+				// The browsable path of the NGINX rewrite path
+				return strings.TrimSuffix(httpPath.Path, "(.*)")
 			}
 		}
 	}
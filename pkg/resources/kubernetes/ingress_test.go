@@ -3,12 +3,18 @@ package kubernetes
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/resources"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetIngressURL(t *testing.T) {
@@ -68,5 +74,88 @@ func TestGetIngressURL(t *testing.T) {
 	}
 
 	url := GetIngressURL(ingress)
-	assert.Equal(t, "https://192.168.99.9/(.*)", url)
+	assert.Equal(t, "https://192.168.99.9/", url)
+
+	// The host of the ingress takes precedence over its address
+	ingress.Spec.Rules[0].Host = "hawtio.example.com"
+	url = GetIngressURL(ingress)
+	assert.Equal(t, "https://hawtio.example.com/", url)
+}
+
+func TestNewIngress(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	apiSpec := &capabilities.ApiServerSpec{}
+	servingSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online-tls-serving"}}
+
+	// Defaults to the NGINX ingress controller
+	ingress := NewIngress(hawtio, apiSpec, servingSecret, logr.Discard())
+	assert.Nil(t, ingress.Spec.IngressClassName)
+	assert.Equal(t, "HTTPS", ingress.Annotations["nginx.ingress.kubernetes.io/backend-protocol"])
+	assert.Equal(t, "/$1", ingress.Annotations["nginx.ingress.kubernetes.io/rewrite-target"])
+	require.Len(t, ingress.Spec.Rules, 1)
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/(.*)", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, "hawtio-online-tls-serving", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, "https://*/", GetIngressURL(ingress))
+
+	hawtio.Spec.Ingress = hawtiov2.HawtioIngress{
+		ClassName:   "traefik",
+		Hosts:       []string{"hawtio.example.com", "console.example.com"},
+		Path:        "/hawtio",
+		TLSSecret:   corev1.LocalObjectReference{Name: "hawtio-tls"},
+		Annotations: map[string]string{"traefik.ingress.kubernetes.io/router.tls": "true"},
+	}
+
+	ingress = NewIngress(hawtio, apiSpec, servingSecret, logr.Discard())
+	assert.Equal(t, "traefik", *ingress.Spec.IngressClassName)
+	assert.Equal(t, map[string]string{"traefik.ingress.kubernetes.io/router.tls": "true"}, ingress.Annotations)
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "hawtio.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, "/hawtio", ingress.Spec.Rules[1].HTTP.Paths[0].Path)
+	assert.Equal(t, networkingv1.IngressTLS{
+		Hosts:      []string{"hawtio.example.com", "console.example.com"},
+		SecretName: "hawtio-tls",
+	}, ingress.Spec.TLS[0])
+	assert.Equal(t, "https://hawtio.example.com/hawtio", GetIngressURL(ingress))
+
+	// The allowed source ranges are not enforced by other ingress controllers
+	hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8", "192.168.0.0/16"}
+	ingress = NewIngress(hawtio, apiSpec, servingSecret, logr.Discard())
+	assert.NotContains(t, ingress.Annotations, NginxWhitelistSourceRangeAnnotation)
+
+	// The NGINX rewrite path serves the console at the ingress path
+	hawtio.Spec.Ingress.ClassName = "nginx"
+	ingress = NewIngress(hawtio, apiSpec, servingSecret, logr.Discard())
+	assert.Equal(t, "/hawtio/(.*)", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, "https://hawtio.example.com/hawtio/", GetIngressURL(ingress))
+	assert.Equal(t, "10.0.0.0/8,192.168.0.0/16", ingress.Annotations[NginxWhitelistSourceRangeAnnotation])
+}
+
+func TestNewIngressWithAdditionalHosts(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	hawtio.Spec.Hosts = []string{"console.example.com"}
+	apiSpec := &capabilities.ApiServerSpec{}
+
+	// The ingress still matches any host, and is reached at its address
+	ingress := NewIngress(hawtio, apiSpec, nil, logr.Discard())
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Empty(t, ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, []string{"console.example.com"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, []string{"https://*/", "https://console.example.com/"}, GetIngressURLs(ingress))
+
+	// The additional host names follow those of the ingress
+	hawtio.Spec.Ingress.Hosts = []string{"hawtio.example.com"}
+	ingress = NewIngress(hawtio, apiSpec, nil, logr.Discard())
+	require.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "hawtio.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "console.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, []string{"hawtio.example.com", "console.example.com"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, "https://hawtio.example.com/", GetIngressURL(ingress))
+	assert.Equal(t, []string{"https://hawtio.example.com/", "https://console.example.com/"}, GetIngressURLs(ingress))
 }
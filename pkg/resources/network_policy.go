@@ -0,0 +1,180 @@
+package resources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+const (
+	defaultIngressControllerNamespace = "ingress-nginx"
+	// The label OpenShift sets on the namespaces of the ingress routers,
+	// including the host network ones
+	openShiftIngressPolicyGroupLabel = "policy-group.network.openshift.io/ingress"
+	namespaceNameLabel               = "kubernetes.io/metadata.name"
+)
+
+func NewDefaultNetworkPolicy(hawtio *hawtiov2.Hawtio) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// IsNetworkPolicyApplicable returns whether a NetworkPolicy should exist for
+// the Hawtio CR. The policy is opt-in as it restricts the pod traffic.
+func IsNetworkPolicyApplicable(hawtio *hawtiov2.Hawtio) bool {
+	return hawtio.Spec.NetworkPolicy.Enabled
+}
+
+func NewNetworkPolicy(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, log logr.Logger) *networkingv1.NetworkPolicy {
+	log.V(util.DebugLogLevel).Info("Reconciling network policy")
+
+	annotations := map[string]string{}
+	PropagateAnnotations(hawtio, annotations, log)
+
+	labels := map[string]string{
+		LabelAppKey: "hawtio",
+	}
+	PropagateLabels(hawtio, labels, log)
+
+	np := NewDefaultNetworkPolicy(hawtio)
+	np.SetLabels(labels)
+	np.SetAnnotations(annotations)
+	np.Spec = networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: LabelsForHawtio(hawtio.Name),
+		},
+		PolicyTypes: []networkingv1.PolicyType{
+			networkingv1.PolicyTypeIngress,
+			networkingv1.PolicyTypeEgress,
+		},
+		Ingress: ingressRules(hawtio, apiSpec),
+		Egress: []networkingv1.NetworkPolicyEgressRule{
+			// The cluster DNS
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					networkPolicyPort(corev1.ProtocolUDP, intstr.FromInt32(53)),
+					networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt32(53)),
+					networkPolicyPort(corev1.ProtocolUDP, intstr.FromInt32(5353)),
+					networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt32(5353)),
+				},
+			},
+			// The API server, whose endpoints are outside the pod network, so that
+			// they can only be selected by address
+			{
+				Ports: []networkingv1.NetworkPolicyPort{
+					networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt32(443)),
+					networkPolicyPort(corev1.ProtocolTCP, intstr.FromInt32(6443)),
+				},
+				To: apiServerPeers(hawtio),
+			},
+			// The Jolokia endpoints of the application pods
+			{
+				To: []networkingv1.NetworkPolicyPeer{jolokiaPeer(hawtio)},
+			},
+		},
+	}
+
+	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New network policy %s", util.JSONToString(np)))
+	return np
+}
+
+// ingressRules returns the rules allowing the traffic to the console port from
+// the means the console is exposed with
+func ingressRules(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) []networkingv1.NetworkPolicyIngressRule {
+	ports := []networkingv1.NetworkPolicyPort{
+		networkPolicyPort(corev1.ProtocolTCP, intstr.FromString(containerPortName)),
+	}
+
+	switch hawtio.Spec.Service.Type {
+	case corev1.ServiceTypeLoadBalancer, corev1.ServiceTypeNodePort:
+		// The clients reach the pods directly, through the nodes, so that
+		// they cannot be selected, and are restricted by the load balancer
+		return []networkingv1.NetworkPolicyIngressRule{{Ports: ports}}
+	}
+
+	peers := ingressPeers(hawtio, apiSpec)
+	if len(peers) == 0 {
+		// The console is not exposed, so that all the ingress traffic is denied
+		return nil
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{{Ports: ports, From: peers}}
+}
+
+func ingressPeers(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+
+	namespace := hawtio.Spec.NetworkPolicy.IngressControllerNamespace
+	switch util.GetExposureType(hawtio, apiSpec) {
+	case hawtiov2.RouteHawtioExposureType, hawtiov2.IngressHawtioExposureType:
+		// The OpenShift routers also serve the ingresses
+		if apiSpec.IsOpenShift4 {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{openShiftIngressPolicyGroupLabel: ""},
+				},
+			})
+		} else if namespace == "" {
+			namespace = defaultIngressControllerNamespace
+		}
+	case hawtiov2.GatewayAPIHawtioExposureType:
+		// The Gateway pods usually run in the namespace of the Gateway
+		if namespace == "" {
+			namespace = hawtio.Spec.HTTPRoute.ParentRef.Namespace
+		}
+		if namespace == "" {
+			namespace = hawtio.Namespace
+		}
+	}
+
+	if namespace != "" {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: namespace},
+			},
+		})
+	}
+
+	return peers
+}
+
+func apiServerPeers(hawtio *hawtiov2.Hawtio) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, cidr := range hawtio.Spec.NetworkPolicy.APIServerCIDRs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	return peers
+}
+
+func jolokiaPeer(hawtio *hawtiov2.Hawtio) networkingv1.NetworkPolicyPeer {
+	if hawtio.Spec.Type == hawtiov2.ClusterHawtioDeploymentType {
+		return networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{},
+		}
+	}
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{},
+	}
+}
+
+func networkPolicyPort(protocol corev1.Protocol, port intstr.IntOrString) networkingv1.NetworkPolicyPort {
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &protocol,
+		Port:     &port,
+	}
+}
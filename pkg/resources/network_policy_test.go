@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+)
+
+func TestNewNetworkPolicy(t *testing.T) {
+	openShiftRouters := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{openShiftIngressPolicyGroupLabel: ""},
+		},
+	}
+	consolePorts := []networkingv1.NetworkPolicyPort{
+		networkPolicyPort(corev1.ProtocolTCP, intstr.FromString(containerPortName)),
+	}
+	from := func(peers ...networkingv1.NetworkPolicyPeer) []networkingv1.NetworkPolicyIngressRule {
+		return []networkingv1.NetworkPolicyIngressRule{{Ports: consolePorts, From: peers}}
+	}
+	namespace := func(name string) networkingv1.NetworkPolicyPeer {
+		return networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{namespaceNameLabel: name},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		deploymentType      hawtiov2.HawtioDeploymentType
+		exposureType        hawtiov2.HawtioExposureType
+		serviceType         corev1.ServiceType
+		ingressNamespace    string
+		apiServerCIDRs      []string
+		isOpenShift         bool
+		routes              bool
+		expectedIngress     []networkingv1.NetworkPolicyIngressRule
+		expectedAPIServer   []networkingv1.NetworkPolicyPeer
+		expectedJolokiaPeer networkingv1.NetworkPolicyPeer
+	}{
+		{
+			name:                "kubernetes namespace",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			expectedIngress:     from(namespace("ingress-nginx")),
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "kubernetes cluster with ingress controller namespace",
+			deploymentType:      hawtiov2.ClusterHawtioDeploymentType,
+			ingressNamespace:    "traefik",
+			expectedIngress:     from(namespace("traefik")),
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "openshift",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			isOpenShift:         true,
+			routes:              true,
+			expectedIngress:     from(openShiftRouters),
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "openshift with ingress controller namespace",
+			deploymentType:      hawtiov2.ClusterHawtioDeploymentType,
+			ingressNamespace:    "ingress-nginx",
+			isOpenShift:         true,
+			expectedIngress:     from(openShiftRouters, namespace("ingress-nginx")),
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "gateway api",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			exposureType:        hawtiov2.GatewayAPIHawtioExposureType,
+			isOpenShift:         true,
+			routes:              true,
+			expectedIngress:     from(namespace("gateways")),
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "load balancer service",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			serviceType:         corev1.ServiceTypeLoadBalancer,
+			expectedIngress:     []networkingv1.NetworkPolicyIngressRule{{Ports: consolePorts}},
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "node port service",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			exposureType:        hawtiov2.NoneHawtioExposureType,
+			serviceType:         corev1.ServiceTypeNodePort,
+			expectedIngress:     []networkingv1.NetworkPolicyIngressRule{{Ports: consolePorts}},
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "not exposed",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			exposureType:        hawtiov2.NoneHawtioExposureType,
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+		{
+			name:                "api server CIDRs",
+			deploymentType:      hawtiov2.NamespaceHawtioDeploymentType,
+			apiServerCIDRs:      []string{"10.0.0.1/32"},
+			expectedIngress:     from(namespace("ingress-nginx")),
+			expectedAPIServer:   []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+			expectedJolokiaPeer: networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hawtio := &hawtiov2.Hawtio{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hawtio-online",
+					Namespace: "hawtio",
+				},
+				Spec: hawtiov2.HawtioSpec{
+					Type:     tt.deploymentType,
+					Exposure: hawtiov2.HawtioExposure{Type: tt.exposureType},
+					Service:  hawtiov2.HawtioService{Type: tt.serviceType},
+					HTTPRoute: hawtiov2.HawtioHTTPRoute{
+						ParentRef: hawtiov2.HawtioGatewayReference{Name: "gateway", Namespace: "gateways"},
+					},
+					NetworkPolicy: hawtiov2.HawtioNetworkPolicy{
+						Enabled:                    true,
+						IngressControllerNamespace: tt.ingressNamespace,
+						APIServerCIDRs:             tt.apiServerCIDRs,
+					},
+				},
+			}
+			apiSpec := &capabilities.ApiServerSpec{IsOpenShift4: tt.isOpenShift, Routes: tt.routes, GatewayAPI: tt.exposureType == hawtiov2.GatewayAPIHawtioExposureType}
+
+			np := NewNetworkPolicy(hawtio, apiSpec, logr.Discard())
+
+			assert.Equal(t, "hawtio-online", np.Name)
+			assert.Equal(t, LabelsForHawtio("hawtio-online"), np.Spec.PodSelector.MatchLabels)
+			assert.ElementsMatch(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, np.Spec.PolicyTypes)
+
+			assert.Equal(t, tt.expectedIngress, np.Spec.Ingress)
+
+			assert.Len(t, np.Spec.Egress, 3)
+			assert.Equal(t, tt.expectedAPIServer, np.Spec.Egress[1].To)
+			assert.Equal(t, []networkingv1.NetworkPolicyPeer{tt.expectedJolokiaPeer}, np.Spec.Egress[2].To)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package openshift
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -13,11 +14,22 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/resources"
 	"github.com/hawtio/hawtio-operator/pkg/util"
 )
 
-const RouteHostGeneratedAnnotation = "openshift.io/host.generated"
+const (
+	RouteHostGeneratedAnnotation = "openshift.io/host.generated"
+	// RouteIPWhitelistAnnotation restricts the client addresses the router accepts
+	RouteIPWhitelistAnnotation = "haproxy.router.openshift.io/ip_whitelist"
+)
+
+// IsRouteApplicable returns whether the Hawtio service is exposed with a route,
+// that is when the route exposure is selected and the cluster supports routes
+func IsRouteApplicable(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
+	return apiSpec.IsOpenShift4 && apiSpec.Routes && util.GetExposureType(hawtio, apiSpec) == hawtiov2.RouteHawtioExposureType
+}
 
 func NewDefaultRoute(hawtio *hawtiov2.Hawtio) *routev1.Route {
 	return &routev1.Route{
@@ -32,6 +44,7 @@ func NewRoute(hawtio *hawtiov2.Hawtio, routeTLSSecret *v1.Secret, caCertRouteSec
 	log.V(util.DebugLogLevel).Info("Reconciling route")
 
 	name := hawtio.Name
+	spec := hawtio.Spec.Route
 
 	annotations := map[string]string{}
 	resources.PropagateAnnotations(hawtio, annotations, log)
@@ -40,32 +53,53 @@ func NewRoute(hawtio *hawtiov2.Hawtio, routeTLSSecret *v1.Secret, caCertRouteSec
 	if hawtio.Spec.RouteHostName == "" {
 		annotations[RouteHostGeneratedAnnotation] = "true"
 	}
+	if ranges := hawtio.Spec.Access.AllowedSourceRanges; len(ranges) > 0 {
+		annotations[RouteIPWhitelistAnnotation] = strings.Join(ranges, " ")
+	}
+	for key, value := range spec.Annotations {
+		annotations[key] = value
+	}
 
 	labels := resources.LabelsForHawtio(hawtio.Name)
 	resources.PropagateLabels(hawtio, labels, log)
+	for key, value := range spec.Labels {
+		labels[key] = value
+	}
 
 	route := NewDefaultRoute(hawtio)
 	route.SetLabels(labels)
 	route.SetAnnotations(annotations)
 	route.Spec = routev1.RouteSpec{
 		Host: hawtio.Spec.RouteHostName,
+		Path: spec.Path,
 		To: routev1.RouteTargetReference{
 			Kind: "Service",
 			Name: name,
 		},
+		WildcardPolicy: GetRouteWildcardPolicy(hawtio),
+	}
+
+	termination := routev1.TLSTerminationReencrypt
+	if spec.Termination != "" {
+		termination = routev1.TLSTerminationType(spec.Termination)
+	}
+	insecurePolicy := routev1.InsecureEdgeTerminationPolicyRedirect
+	if spec.InsecureEdgeTerminationPolicy != "" {
+		insecurePolicy = routev1.InsecureEdgeTerminationPolicyType(spec.InsecureEdgeTerminationPolicy)
 	}
 
 	tlsConfig := &routev1.TLSConfig{
-		Termination:                   routev1.TLSTerminationReencrypt,
-		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		Termination:                   termination,
+		InsecureEdgeTerminationPolicy: insecurePolicy,
 	}
 
-	if routeTLSSecret != nil {
+	// The router does not terminate the passthrough TLS traffic so has no certificate
+	if routeTLSSecret != nil && termination != routev1.TLSTerminationPassthrough {
 		tlsConfig.Key = string(routeTLSSecret.Data["tls.key"])
 		tlsConfig.Certificate = string(routeTLSSecret.Data["tls.crt"])
 		if caCertRouteSecret != nil {
 			key := "tls.crt"
-			if k := hawtio.Spec.Route.CaCert.Key; k != "" {
+			if k := spec.CaCert.Key; k != "" {
 				key = k
 			}
 			tlsConfig.CACertificate = string(caCertRouteSecret.Data[key])
@@ -78,6 +112,36 @@ func NewRoute(hawtio *hawtiov2.Hawtio, routeTLSSecret *v1.Secret, caCertRouteSec
 	return route
 }
 
+func NewDefaultAliasRoute(hawtio *hawtiov2.Hawtio, host string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      util.GetAliasRouteName(hawtio, host),
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// NewAliasRoute creates the route that exposes the Hawtio service at the given
+// additional host name. It is configured as the main route, except for its host,
+// and its wildcard policy, that is always None.
+func NewAliasRoute(hawtio *hawtiov2.Hawtio, host string, routeTLSSecret *v1.Secret, caCertRouteSecret *v1.Secret, log logr.Logger) *routev1.Route {
+	route := NewRoute(hawtio, routeTLSSecret, caCertRouteSecret, log)
+	route.Name = util.GetAliasRouteName(hawtio, host)
+	route.Spec.Host = host
+	route.Spec.WildcardPolicy = routev1.WildcardPolicyNone
+	delete(route.Annotations, RouteHostGeneratedAnnotation)
+
+	return route
+}
+
+// GetRouteWildcardPolicy returns the wildcard policy of the route, that defaults to None
+func GetRouteWildcardPolicy(hawtio *hawtiov2.Hawtio) routev1.WildcardPolicyType {
+	if policy := hawtio.Spec.Route.WildcardPolicy; policy != "" {
+		return routev1.WildcardPolicyType(policy)
+	}
+	return routev1.WildcardPolicyNone
+}
+
 func GetRouteURL(route *routev1.Route) string {
 	var scheme string
 	if route.Spec.TLS != nil && len(route.Spec.TLS.Termination) > 0 {
@@ -118,3 +182,25 @@ func getRouteHost(route *routev1.Route) string {
 
 	return route.Spec.Host
 }
+
+// GetRouteAdmission reports whether the route has been admitted by at least
+// one router. If not, the reason and message of the first rejection, if any,
+// are returned.
+func GetRouteAdmission(route *routev1.Route) (bool, string, string) {
+	reason, message := "", ""
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type != routev1.RouteAdmitted {
+				continue
+			}
+			if condition.Status == v1.ConditionTrue {
+				return true, "", ""
+			}
+			if reason == "" {
+				reason, message = condition.Reason, condition.Message
+			}
+		}
+	}
+
+	return false, reason, message
+}
@@ -0,0 +1,106 @@
+package openshift
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routev1 "github.com/openshift/api/route/v1"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoute(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+
+	// Defaults to reencrypt termination, redirecting the insecure traffic
+	route := NewRoute(hawtio, nil, nil, logr.Discard())
+	assert.Equal(t, resources.LabelsForHawtio(hawtio.Name), route.Labels)
+	assert.Equal(t, "true", route.Annotations[RouteHostGeneratedAnnotation])
+	assert.Equal(t, routev1.TLSTerminationReencrypt, route.Spec.TLS.Termination)
+	assert.Equal(t, routev1.InsecureEdgeTerminationPolicyRedirect, route.Spec.TLS.InsecureEdgeTerminationPolicy)
+	assert.Equal(t, routev1.WildcardPolicyNone, route.Spec.WildcardPolicy)
+	assert.Empty(t, route.Spec.Path)
+
+	hawtio.Spec.RouteHostName = "hawtio.apps.example.com"
+	hawtio.Spec.Route = hawtiov2.HawtioRoute{
+		Termination:                   hawtiov2.EdgeHawtioRouteTermination,
+		InsecureEdgeTerminationPolicy: "Allow",
+		Path:                          "/hawtio",
+		WildcardPolicy:                "Subdomain",
+		Labels:                        map[string]string{"router": "internal"},
+		Annotations:                   map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+	}
+	routeTLSSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hawtio-route-tls"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("certificate"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+
+	route = NewRoute(hawtio, routeTLSSecret, nil, logr.Discard())
+	assert.Equal(t, "internal", route.Labels["router"])
+	assert.Equal(t, map[string]string{"haproxy.router.openshift.io/timeout": "5m"}, route.Annotations)
+	assert.Equal(t, "hawtio.apps.example.com", route.Spec.Host)
+	assert.Equal(t, "/hawtio", route.Spec.Path)
+	assert.Equal(t, routev1.WildcardPolicySubdomain, route.Spec.WildcardPolicy)
+	assert.Equal(t, &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationEdge,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyAllow,
+		Certificate:                   "certificate",
+		Key:                           "key",
+	}, route.Spec.TLS)
+	assert.Equal(t, "https://hawtio.apps.example.com/hawtio", GetRouteURL(route))
+
+	// The router only accepts the allowed source ranges
+	hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8", "192.168.0.0/16"}
+	route = NewRoute(hawtio, routeTLSSecret, nil, logr.Discard())
+	assert.Equal(t, "10.0.0.0/8 192.168.0.0/16", route.Annotations[RouteIPWhitelistAnnotation])
+	hawtio.Spec.Access = hawtiov2.HawtioAccess{}
+
+	// The router has no certificate with passthrough termination
+	hawtio.Spec.Route = hawtiov2.HawtioRoute{
+		Termination:                   hawtiov2.PassthroughHawtioRouteTermination,
+		InsecureEdgeTerminationPolicy: "None",
+	}
+
+	route = NewRoute(hawtio, routeTLSSecret, nil, logr.Discard())
+	assert.Equal(t, &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationPassthrough,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+	}, route.Spec.TLS)
+	assert.Equal(t, "https://hawtio.apps.example.com", GetRouteURL(route))
+}
+
+func TestNewAliasRoute(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	hawtio.Spec.Hosts = []string{"hawtio.example.com", "console.example.com"}
+	hawtio.Spec.Route = hawtiov2.HawtioRoute{
+		Path:           "/hawtio",
+		WildcardPolicy: "Subdomain",
+	}
+
+	route := NewAliasRoute(hawtio, "console.example.com", nil, nil, logr.Discard())
+	assert.Equal(t, "hawtio-online-alias-"+util.ShortHash("console.example.com"), route.Name)
+	assert.Equal(t, route.Name, NewAliasRoute(hawtio, "console.example.com", nil, nil, logr.Discard()).Name)
+	assert.NotEqual(t, route.Name, NewAliasRoute(hawtio, "hawtio.example.com", nil, nil, logr.Discard()).Name)
+	assert.Equal(t, resources.LabelsForHawtio(hawtio.Name), route.Labels)
+	assert.NotContains(t, route.Annotations, RouteHostGeneratedAnnotation)
+	assert.Equal(t, "console.example.com", route.Spec.Host)
+	assert.Equal(t, "/hawtio", route.Spec.Path)
+	assert.Equal(t, routev1.WildcardPolicyNone, route.Spec.WildcardPolicy)
+	assert.Equal(t, "hawtio-online", route.Spec.To.Name)
+	assert.Equal(t, "https://console.example.com/hawtio", GetRouteURL(route))
+}
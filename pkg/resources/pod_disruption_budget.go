@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+func NewDefaultPodDisruptionBudget(hawtio *hawtiov2.Hawtio) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hawtio.Name,
+			Namespace: hawtio.Namespace,
+		},
+	}
+}
+
+// IsPodDisruptionBudgetApplicable returns whether a PodDisruptionBudget should
+// exist for the Hawtio CR. A budget for a single pod would block node drains.
+func IsPodDisruptionBudgetApplicable(hawtio *hawtiov2.Hawtio) bool {
+	return isMultiReplica(hawtio)
+}
+
+func NewPodDisruptionBudget(hawtio *hawtiov2.Hawtio, log logr.Logger) *policyv1.PodDisruptionBudget {
+	log.V(util.DebugLogLevel).Info("Reconciling pod disruption budget")
+
+	annotations := map[string]string{}
+	PropagateAnnotations(hawtio, annotations, log)
+
+	labels := map[string]string{
+		LabelAppKey: "hawtio",
+	}
+	PropagateLabels(hawtio, labels, log)
+
+	spec := hawtio.Spec.PodDisruptionBudget
+
+	pdb := NewDefaultPodDisruptionBudget(hawtio)
+	pdb.SetLabels(labels)
+	pdb.SetAnnotations(annotations)
+	pdb.Spec = policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: LabelsForHawtio(hawtio.Name),
+		},
+	}
+
+	switch {
+	case spec.MinAvailable != nil:
+		minAvailable := *spec.MinAvailable
+		pdb.Spec.MinAvailable = &minAvailable
+	case spec.MaxUnavailable != nil:
+		maxUnavailable := *spec.MaxUnavailable
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt32(1)
+		pdb.Spec.MaxUnavailable = &maxUnavailable
+	}
+
+	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New pod disruption budget %s", util.JSONToString(pdb)))
+	return pdb
+}
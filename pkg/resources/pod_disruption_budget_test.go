@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+func TestNewPodDisruptionBudget(t *testing.T) {
+	minAvailable := intstr.FromString("50%")
+	maxUnavailable := intstr.FromInt32(2)
+
+	tests := []struct {
+		name                   string
+		spec                   hawtiov2.HawtioPodDisruptionBudget
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:                   "default",
+			expectedMaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+		},
+		{
+			name:                 "min available",
+			spec:                 hawtiov2.HawtioPodDisruptionBudget{MinAvailable: &minAvailable},
+			expectedMinAvailable: &minAvailable,
+		},
+		{
+			name:                   "max unavailable",
+			spec:                   hawtiov2.HawtioPodDisruptionBudget{MaxUnavailable: &maxUnavailable},
+			expectedMaxUnavailable: &maxUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replicas := int32(3)
+			hawtio := &hawtiov2.Hawtio{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hawtio-online",
+					Namespace: "hawtio",
+				},
+				Spec: hawtiov2.HawtioSpec{
+					Replicas:            &replicas,
+					PodDisruptionBudget: tt.spec,
+				},
+			}
+
+			pdb := NewPodDisruptionBudget(hawtio, logr.Discard())
+
+			assert.Equal(t, "hawtio-online", pdb.Name)
+			assert.Equal(t, LabelsForHawtio("hawtio-online"), pdb.Spec.Selector.MatchLabels)
+			assert.Equal(t, tt.expectedMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, tt.expectedMaxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
+
+func TestIsPodDisruptionBudgetApplicable(t *testing.T) {
+	hawtio := &hawtiov2.Hawtio{}
+	assert.False(t, IsPodDisruptionBudgetApplicable(hawtio))
+
+	replicas := int32(1)
+	hawtio.Spec.Replicas = &replicas
+	assert.False(t, IsPodDisruptionBudgetApplicable(hawtio))
+
+	replicas = 2
+	assert.True(t, IsPodDisruptionBudgetApplicable(hawtio))
+
+	// Autoscaled deployments have a budget whatever their current replicas
+	replicas = 1
+	hawtio.Spec.Autoscaling = hawtiov2.HawtioAutoscaling{Enabled: true, MaxReplicas: 3}
+	assert.True(t, IsPodDisruptionBudgetApplicable(hawtio))
+}
@@ -0,0 +1,208 @@
+package resources
+
+import (
+	"fmt"
+	"path"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+// reservedVolumeNames are the names of the volumes that may be managed by the operator
+var reservedVolumeNames = []string{
+	serviceSigningSecretVolumeName,
+	clientCertificateSecretVolumeName,
+	onlineConfigMapVolumeName,
+	rbacConfigMapVolumeName,
+	tmpVolumeName,
+	nginxCacheVolumeName,
+	nginxLibVolumeName,
+	nginxRunVolumeName,
+	gatewayTmpVolumeName,
+}
+
+// reservedOnlineEnvVarNames and reservedGatewayEnvVarNames are the names of the
+// environment variables that may be managed by the operator in its containers
+var reservedOnlineEnvVarNames = []string{
+	HawtioTypeEnvVar,
+	HawtioNamespaceEnvVar,
+	HawtioAuthEnvVar,
+	HawtioOAuthClientEnvVar,
+	HawtioSSLKey,
+	HawtioSSLCert,
+	HawtioOnlineLogLvlEnvVar,
+	OpenShiftClusterVersionEnvVar,
+	OpenShiftWebConsoleUrlEnvVar,
+	NginxClientBodyBufferSize,
+	NginxProxyBuffers,
+	NginxSubrequestOutputBufferSize,
+	NginxMasterBurstSizeEnvVar,
+}
+
+var reservedGatewayEnvVarNames = []string{
+	HawtioAuthEnvVar,
+	GatewayWebSvrEnvVar,
+	GatewaySSLKeyEnvVar,
+	GatewaySSLCertEnvVar,
+	GatewaySSLCertCAEnvVar,
+	GatewayLogLvlEnvVar,
+	GatewayMaskIPEnvVar,
+	GatewayRbacEnvVar,
+	HawtioDisableRbacRegistry,
+}
+
+// reservedOnlineMountPaths and reservedGatewayMountPaths are the paths the
+// volumes that may be managed by the operator are mounted at in its containers
+var reservedOnlineMountPaths = []string{
+	path.Join(serverRootDirectory, "online", hawtioConfigKey),
+	serviceSigningSecretVolumeMountPath,
+	serviceSigningSecretVolumeMountPathLegacy,
+	clientCertificateSecretVolumeMountPath,
+	tmpVolumeMountPath,
+	nginxCacheMountPath,
+	nginxLibMountPath,
+	nginxRunMountPath,
+}
+
+var reservedGatewayMountPaths = []string{
+	serviceSigningSecretVolumeMountPath,
+	serviceSigningSecretVolumeMountPathLegacy,
+	rbacConfigMapVolumeMountPath,
+	gatewayTmpVolumeMountPath,
+}
+
+// ReservedVolumeNames returns the names of the volumes that may be managed by
+// the operator, and so cannot be used by the extra volumes of the pod template
+func ReservedVolumeNames() []string {
+	return append([]string(nil), reservedVolumeNames...)
+}
+
+// ReservedContainerNames returns the names of the containers managed by the
+// operator, and so cannot be used by the extra containers of the pod template
+func ReservedContainerNames(hawtio *hawtiov2.Hawtio) []string {
+	return []string{hawtio.Name + "-container", hawtio.Name + "-gateway-container"}
+}
+
+// ReservedEnvVarNames returns the names of the environment variables that may be
+// managed by the operator in the online and gateway containers, and so cannot be
+// used by the extra environment variables of these containers
+func ReservedEnvVarNames() (online []string, gateway []string) {
+	return append([]string(nil), reservedOnlineEnvVarNames...), append([]string(nil), reservedGatewayEnvVarNames...)
+}
+
+// ReservedMountPaths returns the paths the volumes that may be managed by the
+// operator are mounted at in the online and gateway containers, and so cannot
+// be used by the extra volume mounts of these containers
+func ReservedMountPaths() (online []string, gateway []string) {
+	return append([]string(nil), reservedOnlineMountPaths...), append([]string(nil), reservedGatewayMountPaths...)
+}
+
+// PodTemplateConflicts returns the additions of the podTemplate field that are
+// ignored in the pod spec of the deployment, since the operator manages volumes,
+// volume mounts, environment variables or containers with the same name or path.
+// The webhooks reject most of them, but not those depending on the cluster or on
+// the configuration of the operator, nor any if the webhooks are not deployed.
+func PodTemplateConflicts(hawtio *hawtiov2.Hawtio, podSpec *corev1.PodSpec) []string {
+	var conflicts []string
+	template := hawtio.Spec.PodTemplate
+
+	for _, container := range podSpec.Containers {
+		switch container.Name {
+		case hawtio.Name + "-container":
+			conflicts = append(conflicts, containerTemplateConflicts(template.Online, container)...)
+		case hawtio.Name + "-gateway-container":
+			conflicts = append(conflicts, containerTemplateConflicts(template.Gateway, container)...)
+		}
+	}
+
+	for _, volume := range template.Volumes {
+		i := slices.IndexFunc(podSpec.Volumes, func(v corev1.Volume) bool { return v.Name == volume.Name })
+		if i >= 0 && !equality.Semantic.DeepEqual(podSpec.Volumes[i], volume) {
+			conflicts = append(conflicts, fmt.Sprintf("volume %s", volume.Name))
+		}
+	}
+
+	for _, container := range template.Containers {
+		i := slices.IndexFunc(podSpec.Containers, func(c corev1.Container) bool { return c.Name == container.Name })
+		if i >= 0 && !equality.Semantic.DeepEqual(podSpec.Containers[i], container) {
+			conflicts = append(conflicts, fmt.Sprintf("container %s", container.Name))
+		}
+	}
+
+	return conflicts
+}
+
+func containerTemplateConflicts(template hawtiov2.HawtioContainerTemplate, container corev1.Container) []string {
+	var conflicts []string
+
+	for _, env := range template.Env {
+		i := slices.IndexFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name })
+		if i >= 0 && !equality.Semantic.DeepEqual(container.Env[i], env) {
+			conflicts = append(conflicts, fmt.Sprintf("environment variable %s of container %s", env.Name, container.Name))
+		}
+	}
+
+	for _, mount := range template.VolumeMounts {
+		i := slices.IndexFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return m.MountPath == mount.MountPath })
+		if i >= 0 && !equality.Semantic.DeepEqual(container.VolumeMounts[i], mount) {
+			conflicts = append(conflicts, fmt.Sprintf("volume mount %s of container %s", mount.MountPath, container.Name))
+		}
+	}
+
+	return conflicts
+}
+
+// applyPodTemplate merges the additions of the podTemplate field into the generated
+// pod spec. The operator-managed volumes, volume mounts, environment variables and
+// containers take precedence over the additions with the same name.
+func applyPodTemplate(hawtio *hawtiov2.Hawtio, podSpec *corev1.PodSpec, hawtioContainer, gatewayContainer *corev1.Container, log logr.Logger) {
+	template := hawtio.Spec.PodTemplate
+
+	applyContainerTemplate(template.Online, hawtioContainer, log)
+	applyContainerTemplate(template.Gateway, gatewayContainer, log)
+
+	for _, volume := range template.Volumes {
+		if slices.ContainsFunc(podSpec.Volumes, func(v corev1.Volume) bool { return v.Name == volume.Name }) {
+			log.Info(fmt.Sprintf("Ignoring extra volume %s of the pod template, that is managed by the operator", volume.Name))
+			continue
+		}
+		podSpec.Volumes = append(podSpec.Volumes, volume)
+	}
+
+	podSpec.InitContainers = append(podSpec.InitContainers, template.InitContainers...)
+
+	for _, container := range template.Containers {
+		if slices.ContainsFunc(podSpec.Containers, func(c corev1.Container) bool { return c.Name == container.Name }) {
+			log.Info(fmt.Sprintf("Ignoring extra container %s of the pod template, that is managed by the operator", container.Name))
+			continue
+		}
+		podSpec.Containers = append(podSpec.Containers, container)
+	}
+}
+
+func applyContainerTemplate(template hawtiov2.HawtioContainerTemplate, container *corev1.Container, log logr.Logger) {
+	for _, env := range template.Env {
+		if slices.ContainsFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name }) {
+			log.Info(fmt.Sprintf("Ignoring extra environment variable %s of container %s, that is managed by the operator", env.Name, container.Name))
+			continue
+		}
+		container.Env = append(container.Env, env)
+	}
+
+	// Environment variables take precedence over
+	// the sources so these cannot clobber them
+	container.EnvFrom = append(container.EnvFrom, template.EnvFrom...)
+
+	for _, mount := range template.VolumeMounts {
+		if slices.ContainsFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool { return m.MountPath == mount.MountPath }) {
+			log.Info(fmt.Sprintf("Ignoring extra volume mount %s of container %s, that is managed by the operator", mount.MountPath, container.Name))
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts, mount)
+	}
+}
@@ -0,0 +1,93 @@
+package resources
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/go-logr/logr"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/util"
+)
+
+// OperandRelease is a release of the hawtio-online operand supported by the operator
+type OperandRelease struct {
+	// The version of the release, as set in the spec.version field, eg. 3.0
+	Version string `json:"version"`
+	// The tag of the online image
+	OnlineImageTag string `json:"onlineImageTag"`
+	// The tag of the gateway image
+	GatewayImageTag string `json:"gatewayImageTag"`
+	// The environment variables understood by the release
+	EnvVars []string `json:"envVars"`
+	// The path the serving certificate is mounted at in the release images
+	ServingCertificateMountPath string `json:"servingCertificateMountPath"`
+}
+
+// The compatibility matrix of the operand releases
+//
+//go:embed releases.yaml
+var releasesYAML []byte
+
+var operandReleases = mustLoadOperandReleases(releasesYAML)
+
+func mustLoadOperandReleases(data []byte) []OperandRelease {
+	var matrix struct {
+		Releases []OperandRelease `json:"releases"`
+	}
+	if err := yaml.UnmarshalStrict(data, &matrix); err != nil {
+		panic(fmt.Sprintf("invalid operand releases: %v", err))
+	}
+	return matrix.Releases
+}
+
+// SupportedVersions returns the versions of the operand releases that
+// can be selected with the spec.version field of the Hawtio CR
+func SupportedVersions() []string {
+	versions := make([]string, 0, len(operandReleases))
+	for _, release := range operandReleases {
+		versions = append(versions, release.Version)
+	}
+	return versions
+}
+
+// FindOperandRelease returns the operand release with the given version
+func FindOperandRelease(version string) (OperandRelease, bool) {
+	i := slices.IndexFunc(operandReleases, func(r OperandRelease) bool { return r.Version == version })
+	if i < 0 {
+		return OperandRelease{}, false
+	}
+	return operandReleases[i], true
+}
+
+// SelectOperandRelease returns the operand release selected by the spec.version
+// field of the Hawtio CR. It returns nil if no version is specified, in which case
+// the images are those the operator is built and configured for.
+func SelectOperandRelease(hawtio *hawtiov2.Hawtio) (*OperandRelease, error) {
+	version := hawtio.Spec.Version
+	if version == "" {
+		return nil, nil
+	}
+
+	release, ok := FindOperandRelease(version)
+	if !ok {
+		return nil, fmt.Errorf("unsupported version %q, expected one of %s", version, strings.Join(SupportedVersions(), ", "))
+	}
+	return &release, nil
+}
+
+// filterEnvVars drops the environment variables not understood by the release
+func (r *OperandRelease) filterEnvVars(container *corev1.Container, log logr.Logger) {
+	container.Env = slices.DeleteFunc(container.Env, func(env corev1.EnvVar) bool {
+		if slices.Contains(r.EnvVars, env.Name) {
+			return false
+		}
+		log.V(util.DebugLogLevel).Info(fmt.Sprintf("Ignoring environment variable %s of container %s, that is not supported by version %s", env.Name, container.Name, r.Version))
+		return true
+	})
+}
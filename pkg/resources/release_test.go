@@ -0,0 +1,53 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+func TestOperandReleases(t *testing.T) {
+	require.NotEmpty(t, operandReleases)
+
+	versions := map[string]bool{}
+	for _, release := range operandReleases {
+		assert.False(t, versions[release.Version], "duplicate version %s", release.Version)
+		versions[release.Version] = true
+
+		assert.NotEmpty(t, release.OnlineImageTag, release.Version)
+		assert.NotEmpty(t, release.GatewayImageTag, release.Version)
+		assert.NotEmpty(t, release.EnvVars, release.Version)
+		assert.Contains(t, []string{serviceSigningSecretVolumeMountPath, serviceSigningSecretVolumeMountPathLegacy}, release.ServingCertificateMountPath, release.Version)
+	}
+}
+
+func TestSelectOperandRelease(t *testing.T) {
+	hawtio := &hawtiov2.Hawtio{ObjectMeta: metav1.ObjectMeta{Name: "hawtio-online"}}
+
+	release, err := SelectOperandRelease(hawtio)
+	assert.NoError(t, err)
+	assert.Nil(t, release)
+
+	hawtio.Spec.Version = "3.0"
+	release, err = SelectOperandRelease(hawtio)
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.0", release.OnlineImageTag)
+	assert.Equal(t, "3.0.0", release.GatewayImageTag)
+	path, err := servingCertificateMountPath(release, "", "< 9.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, serviceSigningSecretVolumeMountPath, path)
+
+	legacy := &OperandRelease{Version: "1.6", ServingCertificateMountPath: serviceSigningSecretVolumeMountPathLegacy}
+	path, err = servingCertificateMountPath(legacy, "latest", "")
+	require.NoError(t, err)
+	assert.Equal(t, serviceSigningSecretVolumeMountPathLegacy, path)
+
+	hawtio.Spec.Version = "0.1"
+	_, err = SelectOperandRelease(hawtio)
+	assert.ErrorContains(t, err, `unsupported version "0.1"`)
+}
@@ -0,0 +1,101 @@
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+)
+
+const (
+	// The non-root user of the hawtio-online images
+	defaultRunAsUser int64 = 1001
+
+	tmpVolumeName             = "hawtio-online-tmp"
+	tmpVolumeMountPath        = "/tmp"
+	nginxCacheVolumeName      = "hawtio-online-nginx-cache"
+	nginxCacheMountPath       = "/var/cache/nginx"
+	nginxLibVolumeName        = "hawtio-online-nginx-lib"
+	nginxLibMountPath         = "/var/lib/nginx"
+	nginxRunVolumeName        = "hawtio-online-nginx-run"
+	nginxRunMountPath         = "/var/run"
+	gatewayTmpVolumeName      = "hawtio-online-gateway-tmp"
+	gatewayTmpVolumeMountPath = "/tmp"
+)
+
+// newPodSecurityContext returns the security context of the Hawtio pods.
+// The default one complies with the restricted Pod Security Standard. On
+// OpenShift, the user is left for the SCCs to assign, as a fixed user is
+// rejected by the restricted-v2 SCC if outside the namespace range.
+func newPodSecurityContext(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) *corev1.PodSecurityContext {
+	if hawtio.Spec.SecurityContext.Pod != nil {
+		return hawtio.Spec.SecurityContext.Pod
+	}
+
+	runAsNonRoot := true
+	securityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: &runAsNonRoot,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+
+	if !apiSpec.IsOpenShift4 {
+		runAsUser := defaultRunAsUser
+		securityContext.RunAsUser = &runAsUser
+	}
+
+	return securityContext
+}
+
+// newContainerSecurityContext returns the security context of the Hawtio containers.
+func newContainerSecurityContext(hawtio *hawtiov2.Hawtio) *corev1.SecurityContext {
+	var securityContext *corev1.SecurityContext
+	if hawtio.Spec.SecurityContext.Container != nil {
+		securityContext = hawtio.Spec.SecurityContext.Container.DeepCopy()
+	} else {
+		allowPrivilegeEscalation := false
+		securityContext = &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+	}
+
+	if hawtio.Spec.SecurityContext.ReadOnlyRootFilesystem {
+		readOnlyRootFilesystem := true
+		securityContext.ReadOnlyRootFilesystem = &readOnlyRootFilesystem
+	}
+
+	return securityContext
+}
+
+// newWritableVolumes returns the emptyDir volumes, and their mounts in the online
+// and gateway containers, for the paths written to when the root filesystem is read-only
+func newWritableVolumes(hawtio *hawtiov2.Hawtio) ([]corev1.Volume, []corev1.VolumeMount, []corev1.VolumeMount) {
+	if !hawtio.Spec.SecurityContext.ReadOnlyRootFilesystem {
+		return nil, nil, nil
+	}
+
+	volumes := []corev1.Volume{
+		newEmptyDirVolume(tmpVolumeName),
+		newEmptyDirVolume(nginxCacheVolumeName),
+		newEmptyDirVolume(nginxLibVolumeName),
+		newEmptyDirVolume(nginxRunVolumeName),
+		newEmptyDirVolume(gatewayTmpVolumeName),
+	}
+
+	onlineMounts := []corev1.VolumeMount{
+		newVolumeMount(tmpVolumeName, tmpVolumeMountPath, ""),
+		newVolumeMount(nginxCacheVolumeName, nginxCacheMountPath, ""),
+		newVolumeMount(nginxLibVolumeName, nginxLibMountPath, ""),
+		newVolumeMount(nginxRunVolumeName, nginxRunMountPath, ""),
+	}
+
+	gatewayMounts := []corev1.VolumeMount{
+		newVolumeMount(gatewayTmpVolumeName, gatewayTmpVolumeMountPath, ""),
+	}
+
+	return volumes, onlineMounts, gatewayMounts
+}
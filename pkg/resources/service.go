@@ -37,6 +37,9 @@ func NewService(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, lo
 		"service.beta.openshift.io/serving-cert-secret-name": name + "-tls-serving",
 	}
 	PropagateAnnotations(hawtio, annotations, log)
+	for key, value := range hawtio.Spec.Service.Annotations {
+		annotations[key] = value
+	}
 
 	labels := map[string]string{
 		LabelAppKey: "hawtio",
@@ -61,10 +64,33 @@ func NewService(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, lo
 			},
 		},
 		Selector:                 LabelsForHawtio(name),
-		SessionAffinity:          "None",
+		SessionAffinity:          corev1.ServiceAffinityNone,
 		PublishNotReadyAddresses: true,
 	}
 
+	spec := hawtio.Spec.Service
+	if spec.Type != "" {
+		service.Spec.Type = spec.Type
+	}
+	if spec.Type == corev1.ServiceTypeLoadBalancer {
+		service.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+		if len(spec.LoadBalancerSourceRanges) == 0 {
+			service.Spec.LoadBalancerSourceRanges = hawtio.Spec.Access.AllowedSourceRanges
+		}
+	}
+	service.Spec.IPFamilies = spec.IPFamilies
+	service.Spec.IPFamilyPolicy = spec.IPFamilyPolicy
+	if spec.SessionAffinity == corev1.ServiceAffinityClientIP {
+		service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		if spec.SessionAffinityTimeoutSeconds != nil {
+			service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+				ClientIP: &corev1.ClientIPConfig{
+					TimeoutSeconds: spec.SessionAffinityTimeoutSeconds,
+				},
+			}
+		}
+	}
+
 	log.V(util.DebugLogLevel).Info(fmt.Sprintf("New service %s", util.JSONToString(service)))
 	return service
 }
@@ -2,6 +2,9 @@ package resources
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 
@@ -10,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
 	"github.com/hawtio/hawtio-operator/pkg/util"
 )
 
@@ -22,34 +26,80 @@ func NewDefaultServiceAccount(hawtio *hawtiov2.Hawtio) *corev1.ServiceAccount {
 	}
 }
 
+// The prefixes of the service account annotations that register it as an OAuth client
+const (
+	OAuthRedirectURIAnnotationPrefix       = "serviceaccounts.openshift.io/oauth-redirecturi."
+	OAuthRedirectReferenceAnnotationPrefix = "serviceaccounts.openshift.io/oauth-redirectreference."
+)
+
+// NewServiceAccount returns the service account the console runs as
 func NewServiceAccount(hawtio *hawtiov2.Hawtio, log logr.Logger) (*corev1.ServiceAccount, error) {
 	log.V(util.DebugLogLevel).Info("New service account")
 
+	labels := LabelsForHawtio(hawtio.Name)
+	PropagateLabels(hawtio, labels, log)
+
+	sa := NewDefaultServiceAccount(hawtio)
+	sa.SetLabels(labels)
+	return sa, nil
+}
+
+// NewOAuthRedirectAnnotations returns the annotations of the service account
+// that register it as an OAuth client. In namespace mode, the service account is
+// the OAuth client of the console, which redirects to its routes, or otherwise
+// to the given URLs the console is exposed at.
+func NewOAuthRedirectAnnotations(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec, urls []string) (map[string]string, error) {
 	annotations := make(map[string]string)
 
-	if hawtio.Spec.Type == hawtiov2.NamespaceHawtioDeploymentType {
-		//
-		// hawtio is in namespace mode so utilize sa as oauthclient
-		//
-		externalRoutes := hawtio.Spec.ExternalRoutes
-		routes := append(externalRoutes, hawtio.Name)
-		for _, name := range routes {
-			ref, err := createRedirectReferenceString(name)
-			if err != nil {
-				return nil, err
+	if hawtio.Spec.Type != hawtiov2.NamespaceHawtioDeploymentType {
+		return annotations, nil
+	}
+
+	//
+	// hawtio is in namespace mode so utilize sa as oauthclient
+	//
+	routes := slices.Clone(hawtio.Spec.ExternalRoutes)
+	if util.GetExposureType(hawtio, apiSpec) == hawtiov2.RouteHawtioExposureType {
+		routes = append(routes, hawtio.Name)
+		for _, host := range hawtio.Spec.Hosts {
+			routes = append(routes, util.GetAliasRouteName(hawtio, host))
+		}
+	} else {
+		// There is no route to reference, so redirect to the URLs
+		// the console has been exposed at, eg. by an ingress, keyed by
+		// a digest of the URL, so that the keys are stable and distinct
+		// from the route names
+		for _, url := range urls {
+			// Wildcard host names are not valid redirect URIs
+			if strings.Contains(url, "*") {
+				continue
 			}
-			annotations["serviceaccounts.openshift.io/oauth-redirecturi."+name] = "https://"
-			annotations["serviceaccounts.openshift.io/oauth-redirectreference."+name] = ref
+			annotations[OAuthRedirectURIAnnotationPrefix+GetRedirectURIName(hawtio, url)] = url
+		}
+	}
+	for _, name := range routes {
+		ref, err := createRedirectReferenceString(name)
+		if err != nil {
+			return nil, err
 		}
+		annotations[OAuthRedirectURIAnnotationPrefix+name] = "https://"
+		annotations[OAuthRedirectReferenceAnnotationPrefix+name] = ref
 	}
 
-	labels := LabelsForHawtio(hawtio.Name)
-	PropagateLabels(hawtio, labels, log)
+	return annotations, nil
+}
 
-	sa := NewDefaultServiceAccount(hawtio)
-	sa.SetLabels(labels)
-	sa.SetAnnotations(annotations)
-	return sa, nil
+// IsOAuthRedirectAnnotation returns whether the given annotation key registers
+// the service account as an OAuth client
+func IsOAuthRedirectAnnotation(key string) bool {
+	return strings.HasPrefix(key, OAuthRedirectURIAnnotationPrefix) ||
+		strings.HasPrefix(key, OAuthRedirectReferenceAnnotationPrefix)
+}
+
+// GetRedirectURIName returns the name under which the given URL is registered
+// as a redirect URI in the annotations of the service account
+func GetRedirectURIName(hawtio *hawtiov2.Hawtio, url string) string {
+	return fmt.Sprintf("%s-url-%s", hawtio.Name, util.ShortHash(url))
 }
 
 func createRedirectReferenceString(name string) (string, error) {
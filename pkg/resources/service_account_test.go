@@ -3,9 +3,9 @@ package resources
 import (
 	"testing"
 
-	"github.com/go-logr/logr"
-
 	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+	"github.com/hawtio/hawtio-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -23,18 +23,42 @@ func TestAnnotations(t *testing.T) {
 			Type: hawtiov2.NamespaceHawtioDeploymentType,
 		},
 	}
-	log := logr.Discard()
+	apiSpec := &capabilities.ApiServerSpec{
+		IsOpenShift4: true,
+		Routes:       true,
+	}
 
-	sa, err := NewServiceAccount(hawtio, log)
+	annotations, err := NewOAuthRedirectAnnotations(hawtio, apiSpec, nil)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, sa.Annotations["serviceaccounts.openshift.io/oauth-redirectreference.hawtio-online"])
-	assert.NotEmpty(t, sa.Annotations["serviceaccounts.openshift.io/oauth-redirectreference.one"])
-	assert.NotEmpty(t, sa.Annotations["serviceaccounts.openshift.io/oauth-redirectreference.two"])
-	assert.NotEmpty(t, sa.Annotations["serviceaccounts.openshift.io/oauth-redirectreference.three"])
+	assert.NotEmpty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.hawtio-online"])
+	assert.NotEmpty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.one"])
+	assert.NotEmpty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.two"])
+	assert.NotEmpty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.three"])
 
 	hawtio.Spec.ExternalRoutes = []string{}
-	sa, err = NewServiceAccount(hawtio, log)
+	annotations, err = NewOAuthRedirectAnnotations(hawtio, apiSpec, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.hawtio-online"])
+
+	// Each additional host name is exposed with its own route
+	hawtio.Spec.Hosts = []string{"console.example.com"}
+	annotations, err = NewOAuthRedirectAnnotations(hawtio, apiSpec, nil)
 	assert.NoError(t, err)
-	assert.NotEmpty(t, sa.Annotations["serviceaccounts.openshift.io/oauth-redirectreference.hawtio-online"])
+	aliasName := util.GetAliasRouteName(hawtio, "console.example.com")
+	assert.Contains(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference."+aliasName], `"name":"`+aliasName+`"`)
 
+	// Exposed with an ingress, the redirect URI is the one of the console
+	hawtio.Spec.Exposure.Type = hawtiov2.IngressHawtioExposureType
+	hawtio.Status.URLs = []string{"https://stale.example.com/"}
+	annotations, err = NewOAuthRedirectAnnotations(hawtio, apiSpec, []string{"https://hawtio.example.com/"})
+	assert.NoError(t, err)
+	assert.Empty(t, annotations["serviceaccounts.openshift.io/oauth-redirectreference.hawtio-online"])
+	assert.Equal(t, "https://hawtio.example.com/", annotations["serviceaccounts.openshift.io/oauth-redirecturi."+GetRedirectURIName(hawtio, "https://hawtio.example.com/")])
+
+	// Every URL the console is exposed at is a redirect URI, bar wildcard ones
+	annotations, err = NewOAuthRedirectAnnotations(hawtio, apiSpec, []string{"https://*", "https://hawtio.example.com/", "https://console.example.com/"})
+	assert.NoError(t, err)
+	assert.Len(t, annotations, 2)
+	assert.Equal(t, "https://hawtio.example.com/", annotations["serviceaccounts.openshift.io/oauth-redirecturi."+GetRedirectURIName(hawtio, "https://hawtio.example.com/")])
+	assert.Equal(t, "https://console.example.com/", annotations["serviceaccounts.openshift.io/oauth-redirecturi."+GetRedirectURIName(hawtio, "https://console.example.com/")])
 }
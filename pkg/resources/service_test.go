@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+)
+
+func TestNewService(t *testing.T) {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.Name = "hawtio-online"
+	hawtio.Namespace = "hawtio"
+	apiSpec := &capabilities.ApiServerSpec{}
+
+	service := NewService(hawtio, apiSpec, logr.Discard())
+	assert.Empty(t, service.Spec.Type)
+	assert.Equal(t, corev1.ServiceAffinityNone, service.Spec.SessionAffinity)
+	assert.Nil(t, service.Spec.SessionAffinityConfig)
+	assert.Equal(t, int32(SSLServicePort), service.Spec.Ports[0].Port)
+
+	dualStack := corev1.IPFamilyPolicyPreferDualStack
+	timeout := int32(3600)
+	hawtio.Spec.Service = hawtiov2.HawtioService{
+		Type:                          corev1.ServiceTypeLoadBalancer,
+		Annotations:                   map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+		LoadBalancerSourceRanges:      []string{"10.0.0.0/8"},
+		IPFamilies:                    []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		IPFamilyPolicy:                &dualStack,
+		SessionAffinity:               corev1.ServiceAffinityClientIP,
+		SessionAffinityTimeoutSeconds: &timeout,
+	}
+
+	service = NewService(hawtio, apiSpec, logr.Discard())
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, "true", service.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	assert.Equal(t, "hawtio-online-tls-serving", service.Annotations["service.beta.openshift.io/serving-cert-secret-name"])
+	assert.Equal(t, []string{"10.0.0.0/8"}, service.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}, service.Spec.IPFamilies)
+	assert.Equal(t, &dualStack, service.Spec.IPFamilyPolicy)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, service.Spec.SessionAffinity)
+	assert.Equal(t, &timeout, service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)
+
+	// The allowed source ranges of the console apply to the load balancer,
+	// unless its own source ranges are specified
+	hawtio.Spec.Access.AllowedSourceRanges = []string{"192.168.0.0/16"}
+	service = NewService(hawtio, apiSpec, logr.Discard())
+	assert.Equal(t, []string{"10.0.0.0/8"}, service.Spec.LoadBalancerSourceRanges)
+
+	hawtio.Spec.Service.LoadBalancerSourceRanges = nil
+	service = NewService(hawtio, apiSpec, logr.Discard())
+	assert.Equal(t, []string{"192.168.0.0/16"}, service.Spec.LoadBalancerSourceRanges)
+
+	// The source ranges only apply to load balancers
+	hawtio.Spec.Service.Type = corev1.ServiceTypeNodePort
+	service = NewService(hawtio, apiSpec, logr.Discard())
+	assert.Equal(t, corev1.ServiceTypeNodePort, service.Spec.Type)
+	assert.Empty(t, service.Spec.LoadBalancerSourceRanges)
+}
@@ -42,3 +42,14 @@ func newConfigMapVolume(configMapName string, volumeName string) corev1.Volume {
 
 	return volume
 }
+
+func newEmptyDirVolume(volumeName string) corev1.Volume {
+	volume := corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+
+	return volume
+}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 
@@ -15,6 +17,10 @@ func IsSSL(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
 	sslLogMsg := "Should deployment use SSL: "
 
 	if apiSpec.IsOpenShift4 {
+		if isEdgeTerminatedRoute(hawtio, apiSpec) {
+			log.V(DebugLogLevel).Info(fmt.Sprintf("%s false [Using OpenShift edge-terminated route]\n", sslLogMsg))
+			return false // The router forwards plain HTTP
+		}
 		sslLogMsg = fmt.Sprintf("%s true [Using OpenShift]\n", sslLogMsg)
 		return true // Always on for OpenShift 4+
 	}
@@ -38,3 +44,58 @@ func JSONToString(v interface{}) string {
 
 	return string(j)
 }
+
+// GetExposureType returns how the Hawtio service is exposed outside the cluster,
+// either the type set in the CR, or the default one for the cluster:
+// a route on OpenShift, an HTTPRoute when a parent Gateway is referenced and
+// the cluster supports the Gateway API, and an ingress otherwise
+func GetExposureType(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) hawtiov2.HawtioExposureType {
+	if exposureType := hawtio.Spec.Exposure.Type; exposureType != "" {
+		return exposureType
+	}
+
+	if apiSpec.IsOpenShift4 && apiSpec.Routes {
+		return hawtiov2.RouteHawtioExposureType
+	}
+
+	if apiSpec.GatewayAPI && hawtio.Spec.HTTPRoute.ParentRef.Name != "" {
+		return hawtiov2.GatewayAPIHawtioExposureType
+	}
+
+	return hawtiov2.IngressHawtioExposureType
+}
+
+// GetAliasRouteName returns the name of the route that exposes the Hawtio
+// service at the given additional host name. It is derived from the host
+// name, so that the routes of the other host names are not renamed when
+// the host names are reordered or removed.
+func GetAliasRouteName(hawtio *hawtiov2.Hawtio, host string) string {
+	return fmt.Sprintf("%s-alias-%s", hawtio.Name, ShortHash(host))
+}
+
+// ShortHash returns a short and stable digest of the given value, that is
+// suitable for use in resource names and annotation keys
+func ShortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// GetStatusURLs returns all the URLs the Hawtio console is exposed at, as
+// reported in its status, falling back to the single URL reported by former
+// versions of the operator
+func GetStatusURLs(hawtio *hawtiov2.Hawtio) []string {
+	if len(hawtio.Status.URLs) > 0 {
+		return hawtio.Status.URLs
+	}
+	if hawtio.Status.URL != "" {
+		return []string{hawtio.Status.URL}
+	}
+	return nil
+}
+
+// isEdgeTerminatedRoute returns whether the Hawtio service is exposed with a route,
+// whose TLS termination is edge
+func isEdgeTerminatedRoute(hawtio *hawtiov2.Hawtio, apiSpec *capabilities.ApiServerSpec) bool {
+	return apiSpec.Routes && GetExposureType(hawtio, apiSpec) == hawtiov2.RouteHawtioExposureType &&
+		hawtio.Spec.Route.Termination == hawtiov2.EdgeHawtioRouteTermination
+}
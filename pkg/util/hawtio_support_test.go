@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/capabilities"
+)
+
+func TestGetExposureType(t *testing.T) {
+	openShift := &capabilities.ApiServerSpec{IsOpenShift4: true, Routes: true, GatewayAPI: true}
+	kubernetes := &capabilities.ApiServerSpec{}
+	gatewayAPI := &capabilities.ApiServerSpec{GatewayAPI: true}
+
+	hawtio := hawtiov2.NewHawtio()
+	assert.Equal(t, hawtiov2.RouteHawtioExposureType, GetExposureType(hawtio, openShift))
+	assert.Equal(t, hawtiov2.IngressHawtioExposureType, GetExposureType(hawtio, kubernetes))
+	assert.Equal(t, hawtiov2.IngressHawtioExposureType, GetExposureType(hawtio, gatewayAPI))
+
+	// Referencing a Gateway selects the Gateway API, if supported, on Kubernetes
+	hawtio.Spec.HTTPRoute.ParentRef.Name = "public"
+	assert.Equal(t, hawtiov2.RouteHawtioExposureType, GetExposureType(hawtio, openShift))
+	assert.Equal(t, hawtiov2.IngressHawtioExposureType, GetExposureType(hawtio, kubernetes))
+	assert.Equal(t, hawtiov2.GatewayAPIHawtioExposureType, GetExposureType(hawtio, gatewayAPI))
+
+	hawtio.Spec.Exposure.Type = hawtiov2.NoneHawtioExposureType
+	assert.Equal(t, hawtiov2.NoneHawtioExposureType, GetExposureType(hawtio, openShift))
+	assert.Equal(t, hawtiov2.NoneHawtioExposureType, GetExposureType(hawtio, kubernetes))
+}
+
+func TestIsSSL(t *testing.T) {
+	openShift := &capabilities.ApiServerSpec{IsOpenShift4: true, Routes: true}
+	kubernetes := &capabilities.ApiServerSpec{}
+
+	hawtio := hawtiov2.NewHawtio()
+	assert.True(t, IsSSL(hawtio, openShift))
+	assert.True(t, IsSSL(hawtio, kubernetes))
+
+	internalSSL := false
+	hawtio.Spec.Auth.InternalSSL = &internalSSL
+	assert.True(t, IsSSL(hawtio, openShift))
+	assert.False(t, IsSSL(hawtio, kubernetes))
+
+	// The router forwards plain HTTP with edge termination
+	hawtio.Spec.Auth.InternalSSL = nil
+	hawtio.Spec.Route.Termination = hawtiov2.EdgeHawtioRouteTermination
+	assert.False(t, IsSSL(hawtio, openShift))
+	hawtio.Spec.Exposure.Type = hawtiov2.IngressHawtioExposureType
+	assert.True(t, IsSSL(hawtio, openShift))
+}
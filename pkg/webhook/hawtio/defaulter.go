@@ -0,0 +1,29 @@
+package hawtio
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+)
+
+// +kubebuilder:webhook:path=/mutate-hawt-io-v2-hawtio,mutating=true,failurePolicy=fail,sideEffects=None,groups=hawt.io,resources=hawtios,verbs=create;update,versions=v2,name=mhawtio.hawt.io,admissionReviewVersions=v1
+
+// Defaulter fills the unset fields of Hawtio CRs on admission,
+// so that the stored object is fully explicit.
+type Defaulter struct{}
+
+var _ admission.Defaulter[*hawtiov2.Hawtio] = &Defaulter{}
+
+// NewDefaulter creates a Defaulter
+func NewDefaulter() *Defaulter {
+	return &Defaulter{}
+}
+
+// Default applies the operator defaults to the Hawtio CR
+func (d *Defaulter) Default(ctx context.Context, hawtio *hawtiov2.Hawtio) error {
+	resources.ApplyDefaults(hawtio)
+	return nil
+}
@@ -0,0 +1,65 @@
+package hawtio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+)
+
+func TestDefault(t *testing.T) {
+	hawtio := &hawtiov2.Hawtio{}
+
+	err := NewDefaulter().Default(context.TODO(), hawtio)
+	require.NoError(t, err)
+
+	spec := hawtio.Spec
+	assert.Equal(t, hawtiov2.ClusterHawtioDeploymentType, spec.Type)
+	assert.Equal(t, int32(1), *spec.Replicas)
+	assert.True(t, *spec.Auth.InternalSSL)
+	assert.Equal(t, resources.HawtioOnlineLogLvlValue, spec.Logging.OnlineLogLevel)
+	assert.Equal(t, resources.GatewayLogLvlValue, spec.Logging.GatewayLogLevel)
+	assert.Equal(t, resources.GatewayMaskIPValue, spec.Logging.MaskIPAddresses)
+	assert.Equal(t, int32(resources.OnlineReadinessPeriodValue), *spec.HealthChecks.OnlineReadinessPeriod)
+	assert.Equal(t, int32(resources.OnlineLivenessPeriodValue), *spec.HealthChecks.OnlineLivenessPeriod)
+	assert.Equal(t, int32(resources.GatewayReadinessPeriodValue), *spec.HealthChecks.GatewayReadinessPeriod)
+	assert.Equal(t, int32(resources.GatewayLivenessPeriodValue), *spec.HealthChecks.GatewayLivenessPeriod)
+}
+
+func TestDefaultPreservesExplicitValues(t *testing.T) {
+	replicas := int32(3)
+	internalSSL := false
+	period := int32(42)
+
+	hawtio := &hawtiov2.Hawtio{
+		Spec: hawtiov2.HawtioSpec{
+			Type:     hawtiov2.NamespaceHawtioDeploymentType,
+			Replicas: &replicas,
+			Auth: hawtiov2.HawtioAuth{
+				InternalSSL: &internalSSL,
+			},
+			Logging: hawtiov2.HawtioLogging{
+				OnlineLogLevel:  "warn",
+				GatewayLogLevel: "debug",
+				MaskIPAddresses: "true",
+			},
+			HealthChecks: hawtiov2.HawtioHealthCheckPeriods{
+				OnlineReadinessPeriod: &period,
+			},
+		},
+	}
+	expected := hawtio.Spec.DeepCopy()
+
+	err := NewDefaulter().Default(context.TODO(), hawtio)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Type, hawtio.Spec.Type)
+	assert.Equal(t, expected.Replicas, hawtio.Spec.Replicas)
+	assert.Equal(t, expected.Auth, hawtio.Spec.Auth)
+	assert.Equal(t, expected.Logging, hawtio.Spec.Logging)
+	assert.Equal(t, period, *hawtio.Spec.HealthChecks.OnlineReadinessPeriod)
+}
@@ -0,0 +1,683 @@
+package hawtio
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+	kresources "github.com/hawtio/hawtio-operator/pkg/resources/kubernetes"
+)
+
+var validatorLog = logf.Log.WithName("webhook_hawtio_validator")
+
+var (
+	// A single nginx size, eg. 256k, 10m
+	nginxSizeRegex = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+	// The number and size of nginx buffers, eg. 16 128k
+	nginxBuffersRegex = regexp.MustCompile(`^[0-9]+\s+[0-9]+[kKmMgG]?$`)
+	// A plain count, eg. 5000
+	nginxCountRegex = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// OnlineLogLevels are the log levels supported by the online (nginx) container
+var OnlineLogLevels = []string{"emerg", "alert", "crit", "error", "warn", "notice", "info"}
+
+// GatewayLogLevels are the log levels supported by the gateway container
+var GatewayLogLevels = []string{"info", "debug"}
+
+// +kubebuilder:webhook:path=/validate-hawt-io-v2-hawtio,mutating=false,failurePolicy=fail,sideEffects=None,groups=hawt.io,resources=hawtios,verbs=create;update,versions=v2,name=vhawtio.hawt.io,admissionReviewVersions=v1
+
+// Validator validates Hawtio CRs on admission, so that invalid specs
+// are rejected up-front rather than discovered at reconcile time.
+type Validator struct {
+	// Reads directly from the API server since
+	// the manager cache only holds hawtio labelled resources
+	reader client.Reader
+}
+
+var _ admission.Validator[*hawtiov2.Hawtio] = &Validator{}
+
+// NewValidator creates a Validator that checks for the existence
+// of referenced resources using the given reader
+func NewValidator(reader client.Reader) *Validator {
+	return &Validator{reader: reader}
+}
+
+// ValidateCreate validates the Hawtio CR on creation
+func (v *Validator) ValidateCreate(ctx context.Context, hawtio *hawtiov2.Hawtio) (admission.Warnings, error) {
+	return v.validate(ctx, nil, hawtio)
+}
+
+// ValidateUpdate validates the Hawtio CR on update
+func (v *Validator) ValidateUpdate(ctx context.Context, oldHawtio, newHawtio *hawtiov2.Hawtio) (admission.Warnings, error) {
+	return v.validate(ctx, oldHawtio, newHawtio)
+}
+
+// ValidateDelete does nothing since deletion is always allowed
+func (v *Validator) ValidateDelete(ctx context.Context, hawtio *hawtiov2.Hawtio) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator) validate(ctx context.Context, oldHawtio, hawtio *hawtiov2.Hawtio) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateType(hawtio.Spec.Type, specPath.Child("type"))...)
+	allErrs = append(allErrs, validateVersion(oldHawtio, hawtio, specPath.Child("version"))...)
+	allErrs = append(allErrs, validateService(hawtio.Spec.Service, specPath.Child("service"))...)
+	allErrs = append(allErrs, validateRouteHostName(hawtio.Spec.RouteHostName, specPath.Child("routeHostName"))...)
+	allErrs = append(allErrs, validateRoute(hawtio, specPath)...)
+	allErrs = append(allErrs, validateHosts(hawtio.Spec.Hosts, specPath.Child("hosts"))...)
+	allErrs = append(allErrs, validateIngress(hawtio.Spec.Ingress, specPath.Child("ingress"))...)
+	allErrs = append(allErrs, validateHTTPRoute(hawtio.Spec.HTTPRoute, specPath.Child("httpRoute"))...)
+	allErrs = append(allErrs, validateExposure(hawtio, specPath)...)
+	allErrs = append(allErrs, validateAuth(oldHawtio, hawtio, specPath.Child("auth"))...)
+	allErrs = append(allErrs, validateNginx(hawtio.Spec.Nginx, specPath.Child("nginx"))...)
+	allErrs = append(allErrs, validateLogging(hawtio.Spec.Logging, specPath.Child("logging"))...)
+	allErrs = append(allErrs, validateAccess(oldHawtio, hawtio, specPath.Child("access"))...)
+	allErrs = append(allErrs, validateNetworkPolicy(hawtio.Spec.NetworkPolicy, specPath.Child("networkPolicy"))...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(hawtio.Spec.PodDisruptionBudget, specPath.Child("podDisruptionBudget"))...)
+	allErrs = append(allErrs, validateProbes(hawtio.Spec.Probes, specPath.Child("probes"))...)
+	allErrs = append(allErrs, validateAutoscaling(hawtio.Spec.Autoscaling, specPath.Child("autoscaling"))...)
+	allErrs = append(allErrs, validateRollout(hawtio.Spec.Rollout, specPath.Child("rollout"))...)
+	allErrs = append(allErrs, validatePodTemplate(hawtio, specPath.Child("podTemplate"))...)
+
+	warnings := deprecationWarnings(hawtio)
+	warnings = append(warnings, accessWarnings(hawtio)...)
+	warnings = append(warnings, v.rbacConfigMapWarnings(ctx, hawtio)...)
+
+	if len(allErrs) > 0 {
+		return warnings, kerrors.NewInvalid(hawtiov2.SchemeGroupVersion.WithKind("Hawtio").GroupKind(), hawtio.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+func validateType(deploymentType hawtiov2.HawtioDeploymentType, path *field.Path) field.ErrorList {
+	switch deploymentType {
+	case "", hawtiov2.ClusterHawtioDeploymentType, hawtiov2.NamespaceHawtioDeploymentType:
+		return nil
+	}
+
+	return field.ErrorList{
+		field.NotSupported(path, deploymentType, []string{
+			string(hawtiov2.ClusterHawtioDeploymentType),
+			string(hawtiov2.NamespaceHawtioDeploymentType),
+		}),
+	}
+}
+
+func validateVersion(oldHawtio, hawtio *hawtiov2.Hawtio, path *field.Path) field.ErrorList {
+	version := hawtio.Spec.Version
+	if version == "" {
+		return nil // release the operator is built for
+	}
+
+	// Only check versions that are being set, otherwise an existing CR
+	// would no longer be updatable once its version is no longer supported
+	if oldHawtio != nil && oldHawtio.Spec.Version == version {
+		return nil
+	}
+
+	if _, ok := resources.FindOperandRelease(version); !ok {
+		return field.ErrorList{field.NotSupported(path, version, resources.SupportedVersions())}
+	}
+	return nil
+}
+
+func validateRouteHostName(hostName string, path *field.Path) field.ErrorList {
+	if hostName == "" {
+		return nil // host is generated
+	}
+
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(hostName) {
+		allErrs = append(allErrs, field.Invalid(path, hostName, msg))
+	}
+	return allErrs
+}
+
+func validateHosts(hosts []string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, host := range hosts {
+		// The route of an additional host name has no wildcard policy
+		for _, msg := range validation.IsDNS1123Subdomain(host) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), host, msg))
+		}
+		if slices.Contains(hosts[:i], host) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), host))
+		}
+	}
+
+	return allErrs
+}
+
+func validateService(service hawtiov2.HawtioService, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(service.Annotations, path.Child("annotations"))...)
+
+	if len(service.LoadBalancerSourceRanges) > 0 && service.Type != corev1.ServiceTypeLoadBalancer {
+		allErrs = append(allErrs, field.Forbidden(path.Child("loadBalancerSourceRanges"), "only supported with the LoadBalancer type"))
+	}
+	for i, sourceRange := range service.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(sourceRange)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("loadBalancerSourceRanges").Index(i), sourceRange, "must be a CIDR, eg. 10.0.0.0/8"))
+		}
+	}
+
+	families := map[corev1.IPFamily]bool{}
+	for i, family := range service.IPFamilies {
+		familyPath := path.Child("ipFamilies").Index(i)
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			allErrs = append(allErrs, field.NotSupported(familyPath, family, []string{string(corev1.IPv4Protocol), string(corev1.IPv6Protocol)}))
+		} else if families[family] {
+			allErrs = append(allErrs, field.Duplicate(familyPath, family))
+		}
+		families[family] = true
+	}
+	if policy := service.IPFamilyPolicy; policy != nil {
+		switch *policy {
+		case corev1.IPFamilyPolicySingleStack:
+			if len(service.IPFamilies) > 1 {
+				allErrs = append(allErrs, field.Invalid(path.Child("ipFamilyPolicy"), *policy, "a single IP family must be specified with the SingleStack policy"))
+			}
+		case corev1.IPFamilyPolicyPreferDualStack, corev1.IPFamilyPolicyRequireDualStack:
+		default:
+			allErrs = append(allErrs, field.NotSupported(path.Child("ipFamilyPolicy"), *policy, []string{
+				string(corev1.IPFamilyPolicySingleStack),
+				string(corev1.IPFamilyPolicyPreferDualStack),
+				string(corev1.IPFamilyPolicyRequireDualStack),
+			}))
+		}
+	}
+
+	if service.SessionAffinityTimeoutSeconds != nil && service.SessionAffinity != corev1.ServiceAffinityClientIP {
+		allErrs = append(allErrs, field.Forbidden(path.Child("sessionAffinityTimeoutSeconds"), "only supported with the ClientIP session affinity"))
+	}
+
+	return allErrs
+}
+
+func validateRoute(hawtio *hawtiov2.Hawtio, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	route := hawtio.Spec.Route
+	path := specPath.Child("route")
+
+	if route.Termination == hawtiov2.PassthroughHawtioRouteTermination {
+		// The router does not terminate TLS, nor inspect the requests
+		if route.CertSecret.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("certSecret"), "not supported with passthrough termination"))
+		}
+		if route.CaCert.Name != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("caCert"), "not supported with passthrough termination"))
+		}
+		if route.Path != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("path"), "not supported with passthrough termination"))
+		}
+	}
+	if route.InsecureEdgeTerminationPolicy == "Allow" && route.Termination != hawtiov2.EdgeHawtioRouteTermination {
+		allErrs = append(allErrs, field.Invalid(path.Child("insecureEdgeTerminationPolicy"), route.InsecureEdgeTerminationPolicy,
+			"only supported with edge termination"))
+	}
+	if route.Path != "" && !strings.HasPrefix(route.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), route.Path, "must be an absolute path"))
+	}
+	if route.WildcardPolicy == "Subdomain" && hawtio.Spec.RouteHostName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("routeHostName"), "the host name must be specified with the Subdomain wildcard policy"))
+	}
+	allErrs = append(allErrs, metav1validation.ValidateLabels(route.Labels, path.Child("labels"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(route.Annotations, path.Child("annotations"))...)
+
+	return allErrs
+}
+
+func validateExposure(hawtio *hawtiov2.Hawtio, specPath *field.Path) field.ErrorList {
+	exposureType := hawtio.Spec.Exposure.Type
+	switch exposureType {
+	case "", hawtiov2.RouteHawtioExposureType, hawtiov2.IngressHawtioExposureType, hawtiov2.NoneHawtioExposureType:
+		return nil
+	case hawtiov2.GatewayAPIHawtioExposureType:
+		if hawtio.Spec.HTTPRoute.ParentRef.Name == "" {
+			return field.ErrorList{
+				field.Required(specPath.Child("httpRoute", "parentRef", "name"), "the Gateway of the HTTPRoute must be specified to expose the console with the Gateway API"),
+			}
+		}
+		return nil
+	}
+
+	return field.ErrorList{
+		field.NotSupported(specPath.Child("exposure", "type"), exposureType, []string{
+			string(hawtiov2.RouteHawtioExposureType),
+			string(hawtiov2.IngressHawtioExposureType),
+			string(hawtiov2.GatewayAPIHawtioExposureType),
+			string(hawtiov2.NoneHawtioExposureType),
+		}),
+	}
+}
+
+func validateHTTPRoute(httpRoute hawtiov2.HawtioHTTPRoute, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	parentRef := httpRoute.ParentRef
+	parentPath := path.Child("parentRef")
+	if parentRef.Name == "" {
+		if parentRef != (hawtiov2.HawtioGatewayReference{}) || len(httpRoute.Hostnames) > 0 {
+			allErrs = append(allErrs, field.Required(parentPath.Child("name"), "the Gateway of the HTTPRoute must be specified"))
+		}
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(parentRef.Name) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("name"), parentRef.Name, msg))
+		}
+	}
+	if parentRef.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(parentRef.Namespace) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("namespace"), parentRef.Namespace, msg))
+		}
+	}
+	if parentRef.SectionName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(parentRef.SectionName) {
+			allErrs = append(allErrs, field.Invalid(parentPath.Child("sectionName"), parentRef.SectionName, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateHostnames(httpRoute.Hostnames, path.Child("hostnames"))...)
+
+	return allErrs
+}
+
+func validateIngress(ingress hawtiov2.HawtioIngress, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ingress.ClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(ingress.ClassName) {
+			allErrs = append(allErrs, field.Invalid(path.Child("className"), ingress.ClassName, msg))
+		}
+	}
+	allErrs = append(allErrs, validateHostnames(ingress.Hosts, path.Child("hosts"))...)
+	if ingress.Path != "" && !strings.HasPrefix(ingress.Path, "/") {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), ingress.Path, "must be an absolute path"))
+	}
+	if name := ingress.TLSSecret.Name; name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("tlsSecret", "name"), name, msg))
+		}
+	}
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(ingress.Annotations, path.Child("annotations"))...)
+
+	return allErrs
+}
+
+// validateHostnames checks the host names are either DNS subdomains or wildcard ones
+func validateHostnames(hostnames []string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, hostname := range hostnames {
+		msgs := validation.IsDNS1123Subdomain(hostname)
+		if strings.HasPrefix(hostname, "*.") {
+			msgs = validation.IsWildcardDNS1123Subdomain(hostname)
+		}
+		for _, msg := range msgs {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), hostname, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAuth(oldHawtio, hawtio *hawtiov2.Hawtio, path *field.Path) field.ErrorList {
+	expirationDate := hawtio.Spec.Auth.ClientCertExpirationDate
+	if expirationDate == nil {
+		return nil
+	}
+
+	// Only check dates that are being set, otherwise an existing CR
+	// would no longer be updatable once its certificate has expired
+	if oldHawtio != nil && oldHawtio.Spec.Auth.ClientCertExpirationDate.Equal(expirationDate) {
+		return nil
+	}
+
+	datePath := path.Child("clientCertExpirationDate")
+	if expirationDate.IsZero() {
+		return field.ErrorList{field.Invalid(datePath, expirationDate, "must be a valid RFC 3339 date-time")}
+	}
+	if !expirationDate.After(time.Now()) {
+		return field.ErrorList{field.Invalid(datePath, expirationDate.Format(time.RFC3339), "must be in the future")}
+	}
+
+	return nil
+}
+
+func validateNginx(nginx hawtiov2.HawtioNginx, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s := nginx.ClientBodyBufferSize; s != "" && !nginxSizeRegex.MatchString(s) {
+		allErrs = append(allErrs, field.Invalid(path.Child("clientBodyBufferSize"), s, "must be a size, eg. 256k"))
+	}
+	if s := nginx.ProxyBuffers; s != "" && !nginxBuffersRegex.MatchString(s) {
+		allErrs = append(allErrs, field.Invalid(path.Child("proxyBuffers"), s, "must be a number and size of buffers, eg. 16 128k"))
+	}
+	if s := nginx.SubrequestOutputBufferSize; s != "" && !nginxSizeRegex.MatchString(s) {
+		allErrs = append(allErrs, field.Invalid(path.Child("subrequestOutputBufferSize"), s, "must be a size, eg. 10m"))
+	}
+	if s := nginx.MasterBurstSize; s != "" && !nginxCountRegex.MatchString(s) {
+		allErrs = append(allErrs, field.Invalid(path.Child("masterBurstSize"), s, "must be a number of requests, eg. 5000"))
+	}
+
+	return allErrs
+}
+
+func validateLogging(logging hawtiov2.HawtioLogging, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if l := logging.OnlineLogLevel; l != "" && !slices.Contains(OnlineLogLevels, l) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("onlineLogLevel"), l, OnlineLogLevels))
+	}
+	if l := logging.GatewayLogLevel; l != "" && !slices.Contains(GatewayLogLevels, l) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("gatewayLogLevel"), l, GatewayLogLevels))
+	}
+	if m := logging.MaskIPAddresses; m != "" && m != "true" && m != "false" {
+		allErrs = append(allErrs, field.NotSupported(path.Child("maskIPAddresses"), m, []string{"true", "false"}))
+	}
+
+	return allErrs
+}
+
+func validateAccess(oldHawtio, hawtio *hawtiov2.Hawtio, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	ranges := hawtio.Spec.Access.AllowedSourceRanges
+	for i, sourceRange := range ranges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("allowedSourceRanges").Index(i), sourceRange, "must be a CIDR, eg. 10.0.0.0/8"))
+		}
+	}
+
+	// The Gateway API has no standard means to restrict the client addresses.
+	// Only reject the combination when it is being set, otherwise an existing
+	// CR would no longer be updatable.
+	if len(ranges) > 0 && isGatewayAPIRestricted(hawtio) && (oldHawtio == nil || !isGatewayAPIRestricted(oldHawtio)) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("allowedSourceRanges"),
+			"not supported with the GatewayAPI exposure type, the client addresses must be restricted by the Gateway"))
+	}
+
+	return allErrs
+}
+
+// isGatewayAPIRestricted returns whether the console is exposed with the Gateway API,
+// while its access is restricted to some client addresses
+func isGatewayAPIRestricted(hawtio *hawtiov2.Hawtio) bool {
+	return hawtio.Spec.Exposure.Type == hawtiov2.GatewayAPIHawtioExposureType && len(hawtio.Spec.Access.AllowedSourceRanges) > 0
+}
+
+func validateNetworkPolicy(np hawtiov2.HawtioNetworkPolicy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ns := np.IngressControllerNamespace; ns != "" {
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs, field.Invalid(path.Child("ingressControllerNamespace"), ns, msg))
+		}
+	}
+	for i, cidr := range np.APIServerCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("apiServerCIDRs").Index(i), cidr, "must be a CIDR, eg. 10.0.0.1/32"))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePodDisruptionBudget(pdb hawtiov2.HawtioPodDisruptionBudget, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("maxUnavailable"), "may not be specified with minAvailable"))
+	}
+	if v := pdb.MinAvailable; v != nil {
+		allErrs = append(allErrs, validateIntOrPercent(*v, path.Child("minAvailable"))...)
+	}
+	if v := pdb.MaxUnavailable; v != nil {
+		allErrs = append(allErrs, validateIntOrPercent(*v, path.Child("maxUnavailable"))...)
+	}
+
+	return allErrs
+}
+
+func validateAutoscaling(autoscaling hawtiov2.HawtioAutoscaling, path *field.Path) field.ErrorList {
+	if !autoscaling.Enabled {
+		return nil
+	}
+
+	maxPath := path.Child("maxReplicas")
+	if autoscaling.MaxReplicas < 1 {
+		return field.ErrorList{field.Required(maxPath, "must be at least 1 when autoscaling is enabled")}
+	}
+	if minReplicas := autoscaling.MinReplicas; minReplicas != nil && *minReplicas > autoscaling.MaxReplicas {
+		return field.ErrorList{field.Invalid(maxPath, autoscaling.MaxReplicas, fmt.Sprintf("must be greater than or equal to minReplicas (%d)", *minReplicas))}
+	}
+
+	return nil
+}
+
+func validateRollout(rollout hawtiov2.HawtioRollout, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if rollout.Strategy == appsv1.RecreateDeploymentStrategyType {
+		if rollout.MaxSurge != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("maxSurge"), "may not be specified with the Recreate strategy"))
+		}
+		if rollout.MaxUnavailable != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("maxUnavailable"), "may not be specified with the Recreate strategy"))
+		}
+	}
+	if v := rollout.MaxSurge; v != nil {
+		allErrs = append(allErrs, validateIntOrPercent(*v, path.Child("maxSurge"))...)
+	}
+	if v := rollout.MaxUnavailable; v != nil {
+		allErrs = append(allErrs, validateIntOrPercent(*v, path.Child("maxUnavailable"))...)
+	}
+	if isZeroIntOrPercent(rollout.MaxSurge) && isZeroIntOrPercent(rollout.MaxUnavailable) {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxUnavailable"), rollout.MaxUnavailable.String(), "may not be 0 when maxSurge is 0"))
+	}
+
+	if deadline := rollout.ProgressDeadlineSeconds; deadline != nil && *deadline <= rollout.MinReadySeconds {
+		allErrs = append(allErrs, field.Invalid(path.Child("progressDeadlineSeconds"), *deadline, "must be greater than minReadySeconds"))
+	}
+
+	return allErrs
+}
+
+func isZeroIntOrPercent(value *intstr.IntOrString) bool {
+	if value == nil {
+		return false
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	return err == nil && scaled == 0
+}
+
+// validateProbes rejects the probe settings the Deployment would be rejected with
+func validateProbes(probes hawtiov2.HawtioProbes, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateContainerProbes(probes.Online, path.Child("online"))...)
+	allErrs = append(allErrs, validateContainerProbes(probes.Gateway, path.Child("gateway"))...)
+	return allErrs
+}
+
+func validateContainerProbes(probes hawtiov2.HawtioContainerProbes, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateSuccessThreshold(&probes.Liveness, path.Child("liveness"))...)
+	allErrs = append(allErrs, validateSuccessThreshold(probes.Startup, path.Child("startup"))...)
+	return allErrs
+}
+
+func validateSuccessThreshold(probe *hawtiov2.HawtioProbe, path *field.Path) field.ErrorList {
+	if probe == nil || probe.SuccessThreshold == nil || *probe.SuccessThreshold == 1 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path.Child("successThreshold"), *probe.SuccessThreshold, "must be 1")}
+}
+
+// validatePodTemplate rejects the additions that would otherwise be ignored
+// since they conflict with the volumes, volume mounts, environment variables
+// and containers managed by the operator
+func validatePodTemplate(hawtio *hawtiov2.Hawtio, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	template := hawtio.Spec.PodTemplate
+	onlineEnvVarNames, gatewayEnvVarNames := resources.ReservedEnvVarNames()
+	onlineMountPaths, gatewayMountPaths := resources.ReservedMountPaths()
+	allErrs = append(allErrs, validateContainerTemplate(template.Online, onlineEnvVarNames, onlineMountPaths, path.Child("online"))...)
+	allErrs = append(allErrs, validateContainerTemplate(template.Gateway, gatewayEnvVarNames, gatewayMountPaths, path.Child("gateway"))...)
+
+	reservedVolumeNames := resources.ReservedVolumeNames()
+	for i, volume := range template.Volumes {
+		if slices.Contains(reservedVolumeNames, volume.Name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("volumes").Index(i).Child("name"), volume.Name, "is reserved for a volume managed by the operator"))
+		}
+	}
+
+	// The names of the containers and init containers are unique across the pod
+	containerNames := resources.ReservedContainerNames(hawtio)
+	reservedContainerNames := len(containerNames)
+	validateContainerName := func(container corev1.Container, namePath *field.Path) {
+		if i := slices.Index(containerNames, container.Name); i >= 0 && i < reservedContainerNames {
+			allErrs = append(allErrs, field.Invalid(namePath, container.Name, "is reserved for a container managed by the operator"))
+		} else if i >= 0 {
+			allErrs = append(allErrs, field.Duplicate(namePath, container.Name))
+		}
+		containerNames = append(containerNames, container.Name)
+	}
+	for i, container := range template.InitContainers {
+		validateContainerName(container, path.Child("initContainers").Index(i).Child("name"))
+	}
+	for i, container := range template.Containers {
+		validateContainerName(container, path.Child("containers").Index(i).Child("name"))
+	}
+
+	return allErrs
+}
+
+func validateContainerTemplate(template hawtiov2.HawtioContainerTemplate, reservedEnvVarNames, reservedMountPaths []string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	envVarNames := map[string]bool{}
+	for i, env := range template.Env {
+		namePath := path.Child("env").Index(i).Child("name")
+		if slices.Contains(reservedEnvVarNames, env.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, env.Name, "is reserved for an environment variable managed by the operator"))
+		} else if envVarNames[env.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, env.Name))
+		}
+		envVarNames[env.Name] = true
+	}
+
+	mountPaths := map[string]bool{}
+	for i, mount := range template.VolumeMounts {
+		mountPath := path.Child("volumeMounts").Index(i).Child("mountPath")
+		if slices.Contains(reservedMountPaths, mount.MountPath) {
+			allErrs = append(allErrs, field.Invalid(mountPath, mount.MountPath, "is reserved for a volume managed by the operator"))
+		} else if mountPaths[mount.MountPath] {
+			allErrs = append(allErrs, field.Duplicate(mountPath, mount.MountPath))
+		}
+		mountPaths[mount.MountPath] = true
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent checks the value is a non-negative number or a percentage, eg. 50%
+func validateIntOrPercent(value intstr.IntOrString, path *field.Path) field.ErrorList {
+	if value.Type == intstr.String {
+		var allErrs field.ErrorList
+		for _, msg := range validation.IsValidPercent(value.StrVal) {
+			allErrs = append(allErrs, field.Invalid(path, value.StrVal, msg))
+		}
+		return allErrs
+	}
+
+	if value.IntVal < 0 {
+		return field.ErrorList{field.Invalid(path, value.IntVal, "must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+func deprecationWarnings(hawtio *hawtiov2.Hawtio) admission.Warnings {
+	var warnings admission.Warnings
+
+	if hawtio.Spec.Auth.ClientCertCheckSchedule != "" {
+		warnings = append(warnings, "spec.auth.clientCertCheckSchedule is deprecated and ignored, certificate rotation is scheduled by the operator")
+	}
+	if hawtio.Spec.Config.About.AdditionalInfo != "" {
+		warnings = append(warnings, "spec.config.about.additionalInfo is deprecated, use spec.config.about.description instead")
+	}
+
+	return warnings
+}
+
+// accessWarnings warns when the allowed source ranges cannot be enforced on all
+// the means the console is exposed with, that are only known once reconciled
+func accessWarnings(hawtio *hawtiov2.Hawtio) admission.Warnings {
+	if len(hawtio.Spec.Access.AllowedSourceRanges) == 0 {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	if hawtio.Spec.Service.Type == corev1.ServiceTypeNodePort {
+		warnings = append(warnings, "spec.access.allowedSourceRanges are not enforced on the node ports of the NodePort service")
+	}
+	if hawtio.Spec.Exposure.Type == hawtiov2.IngressHawtioExposureType && !kresources.IsNginxIngress(hawtio) {
+		warnings = append(warnings, fmt.Sprintf("spec.access.allowedSourceRanges are not enforced by the %s ingress class, they must be configured with spec.ingress.annotations", hawtio.Spec.Ingress.ClassName))
+	}
+
+	return warnings
+}
+
+// rbacConfigMapWarnings warns, rather than rejects, when the RBAC ConfigMap
+// is not yet usable since it may legitimately be created after the Hawtio CR.
+func (v *Validator) rbacConfigMapWarnings(ctx context.Context, hawtio *hawtiov2.Hawtio) admission.Warnings {
+	name := hawtio.Spec.RBAC.ConfigMap
+	if name == "" || v.reader == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := v.reader.Get(ctx, types.NamespacedName{Namespace: hawtio.Namespace, Name: name}, configMap)
+	if kerrors.IsNotFound(err) {
+		return admission.Warnings{
+			fmt.Sprintf("spec.rbac.configMap: ConfigMap %s not found in namespace %s, the console will not be deployed until it is created", name, hawtio.Namespace),
+		}
+	} else if err != nil {
+		validatorLog.Info("Unable to verify the RBAC ConfigMap", "ConfigMap", name, "Namespace", hawtio.Namespace, "reason", err.Error())
+		return nil
+	}
+
+	if _, ok := configMap.Data[resources.RBACConfigMapKey]; !ok {
+		return admission.Warnings{
+			fmt.Sprintf("spec.rbac.configMap: ConfigMap %s does not contain the key %s, the console will not be deployed until it is added", name, resources.RBACConfigMapKey),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,649 @@
+package hawtio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+	"github.com/hawtio/hawtio-operator/pkg/resources"
+)
+
+func newTestHawtio() *hawtiov2.Hawtio {
+	hawtio := hawtiov2.NewHawtio()
+	hawtio.ObjectMeta = metav1.ObjectMeta{
+		Name:      "hawtio-online",
+		Namespace: "hawtio-ns",
+	}
+	hawtio.Spec.Type = hawtiov2.NamespaceHawtioDeploymentType
+	return hawtio
+}
+
+func newTestValidator(t *testing.T, objs ...client.Object) *Validator {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return NewValidator(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
+
+func TestValidateCreate(t *testing.T) {
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	future := metav1.NewTime(time.Now().AddDate(0, 1, 0))
+
+	tests := []struct {
+		name   string
+		mutate func(hawtio *hawtiov2.Hawtio)
+		errors []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(hawtio *hawtiov2.Hawtio) {},
+		},
+		{
+			name: "valid fully specified",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.RouteHostName = "hawtio.apps.example.com"
+				hawtio.Spec.Auth.ClientCertExpirationDate = &future
+				hawtio.Spec.Nginx = hawtiov2.HawtioNginx{
+					ClientBodyBufferSize:       "256k",
+					ProxyBuffers:               "16 128k",
+					SubrequestOutputBufferSize: "10m",
+					MasterBurstSize:            "5000",
+				}
+				hawtio.Spec.Logging = hawtiov2.HawtioLogging{
+					OnlineLogLevel:  "notice",
+					GatewayLogLevel: "debug",
+					MaskIPAddresses: "true",
+				}
+			},
+		},
+		{
+			name: "supported version",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Version = "3.0"
+			},
+		},
+		{
+			name: "unsupported version",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Version = "1.0"
+			},
+			errors: []string{"spec.version"},
+		},
+		{
+			name: "valid probes",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				threshold := int32(3)
+				hawtio.Spec.Probes.Online.Readiness.SuccessThreshold = &threshold
+				hawtio.Spec.Probes.Gateway.Startup = &hawtiov2.HawtioProbe{FailureThreshold: &threshold}
+			},
+		},
+		{
+			name: "liveness and startup success thresholds",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				threshold := int32(2)
+				hawtio.Spec.Probes.Online.Liveness.SuccessThreshold = &threshold
+				hawtio.Spec.Probes.Gateway.Startup = &hawtiov2.HawtioProbe{SuccessThreshold: &threshold}
+			},
+			errors: []string{"spec.probes.online.liveness.successThreshold", "spec.probes.gateway.startup.successThreshold"},
+		},
+		{
+			name: "valid rollout",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				maxSurge := intstr.FromInt32(0)
+				maxUnavailable := intstr.FromString("50%")
+				deadline := int32(300)
+				hawtio.Spec.Rollout = hawtiov2.HawtioRollout{
+					MaxSurge:                &maxSurge,
+					MaxUnavailable:          &maxUnavailable,
+					MinReadySeconds:         10,
+					ProgressDeadlineSeconds: &deadline,
+				}
+			},
+		},
+		{
+			name: "rolling update parameters with recreate strategy",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				maxSurge := intstr.FromInt32(1)
+				hawtio.Spec.Rollout = hawtiov2.HawtioRollout{
+					Strategy: appsv1.RecreateDeploymentStrategyType,
+					MaxSurge: &maxSurge,
+				}
+			},
+			errors: []string{"spec.rollout.maxSurge"},
+		},
+		{
+			name: "rollout without progress",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				maxSurge := intstr.FromString("0%")
+				maxUnavailable := intstr.FromInt32(0)
+				deadline := int32(10)
+				hawtio.Spec.Rollout = hawtiov2.HawtioRollout{
+					MaxSurge:                &maxSurge,
+					MaxUnavailable:          &maxUnavailable,
+					MinReadySeconds:         30,
+					ProgressDeadlineSeconds: &deadline,
+				}
+			},
+			errors: []string{"spec.rollout.maxUnavailable", "spec.rollout.progressDeadlineSeconds"},
+		},
+		{
+			name: "unsupported type",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Type = "Everywhere"
+			},
+			errors: []string{"spec.type"},
+		},
+		{
+			name: "malformed route host name",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.RouteHostName = "Hawtio_Console.example.com"
+			},
+			errors: []string{"spec.routeHostName"},
+		},
+		{
+			name: "additional host names",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Hosts = []string{"hawtio.example.com", "console.example.com"}
+			},
+		},
+		{
+			name: "malformed and duplicate additional host names",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Hosts = []string{"hawtio.example.com", "*.example.com", "hawtio.example.com"}
+			},
+			errors: []string{"spec.hosts[1]", "spec.hosts[2]"},
+		},
+		{
+			name: "gateway with wildcard host names",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.HTTPRoute = hawtiov2.HawtioHTTPRoute{
+					ParentRef: hawtiov2.HawtioGatewayReference{Name: "public", Namespace: "gateways", SectionName: "https"},
+					Hostnames: []string{"hawtio.example.com", "*.apps.example.com"},
+				}
+			},
+		},
+		{
+			name: "malformed gateway reference",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.HTTPRoute = hawtiov2.HawtioHTTPRoute{
+					ParentRef: hawtiov2.HawtioGatewayReference{Namespace: "Gateways"},
+					Hostnames: []string{"hawtio_console.example.com"},
+				}
+			},
+			errors: []string{
+				"spec.httpRoute.parentRef.name",
+				"spec.httpRoute.parentRef.namespace",
+				"spec.httpRoute.hostnames[0]",
+			},
+		},
+		{
+			name: "load balancer service with session affinity",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				dualStack := corev1.IPFamilyPolicyPreferDualStack
+				timeout := int32(3600)
+				hawtio.Spec.Service = hawtiov2.HawtioService{
+					Type:                          corev1.ServiceTypeLoadBalancer,
+					Annotations:                   map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+					LoadBalancerSourceRanges:      []string{"10.0.0.0/8", "2001:db8::/32"},
+					IPFamilies:                    []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+					IPFamilyPolicy:                &dualStack,
+					SessionAffinity:               corev1.ServiceAffinityClientIP,
+					SessionAffinityTimeoutSeconds: &timeout,
+				}
+			},
+		},
+		{
+			name: "malformed service",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				singleStack := corev1.IPFamilyPolicySingleStack
+				timeout := int32(3600)
+				hawtio.Spec.Service = hawtiov2.HawtioService{
+					Type:                          corev1.ServiceTypeNodePort,
+					LoadBalancerSourceRanges:      []string{"10.0.0.0"},
+					IPFamilies:                    []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol},
+					IPFamilyPolicy:                &singleStack,
+					SessionAffinityTimeoutSeconds: &timeout,
+				}
+			},
+			errors: []string{
+				"spec.service.loadBalancerSourceRanges",
+				"spec.service.loadBalancerSourceRanges[0]",
+				"spec.service.ipFamilies[1]",
+				"spec.service.ipFamilyPolicy",
+				"spec.service.sessionAffinityTimeoutSeconds",
+			},
+		},
+		{
+			name: "edge route with path and shard",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Route = hawtiov2.HawtioRoute{
+					Termination:                   hawtiov2.EdgeHawtioRouteTermination,
+					InsecureEdgeTerminationPolicy: "Allow",
+					Path:                          "/hawtio",
+					Labels:                        map[string]string{"router": "internal"},
+					Annotations:                   map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+				}
+			},
+		},
+		{
+			name: "malformed passthrough route",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Route = hawtiov2.HawtioRoute{
+					CertSecret:                    corev1.LocalObjectReference{Name: "hawtio-route-tls"},
+					Termination:                   hawtiov2.PassthroughHawtioRouteTermination,
+					InsecureEdgeTerminationPolicy: "Allow",
+					Path:                          "hawtio",
+					WildcardPolicy:                "Subdomain",
+					Labels:                        map[string]string{"router": "internal shard"},
+				}
+			},
+			errors: []string{
+				"spec.route.certSecret",
+				"spec.route.path",
+				"spec.route.insecureEdgeTerminationPolicy",
+				"spec.route.path",
+				"spec.routeHostName",
+				"spec.route.labels",
+			},
+		},
+		{
+			name: "ingress with hosts and class",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Ingress = hawtiov2.HawtioIngress{
+					ClassName:   "nginx",
+					Hosts:       []string{"hawtio.example.com", "*.apps.example.com"},
+					Path:        "/hawtio",
+					TLSSecret:   corev1.LocalObjectReference{Name: "hawtio-tls"},
+					Annotations: map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "10m"},
+				}
+			},
+		},
+		{
+			name: "malformed ingress",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Ingress = hawtiov2.HawtioIngress{
+					ClassName:   "NGINX",
+					Hosts:       []string{"hawtio_console.example.com"},
+					Path:        "hawtio",
+					TLSSecret:   corev1.LocalObjectReference{Name: "Hawtio-TLS"},
+					Annotations: map[string]string{"invalid key": "value"},
+				}
+			},
+			errors: []string{
+				"spec.ingress.className",
+				"spec.ingress.hosts[0]",
+				"spec.ingress.path",
+				"spec.ingress.tlsSecret.name",
+				"spec.ingress.annotations",
+			},
+		},
+		{
+			name: "internal-only exposure",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Exposure.Type = hawtiov2.NoneHawtioExposureType
+			},
+		},
+		{
+			name: "gateway API exposure without gateway",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Exposure.Type = hawtiov2.GatewayAPIHawtioExposureType
+			},
+			errors: []string{"spec.httpRoute.parentRef.name"},
+		},
+		{
+			name: "unsupported exposure type",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Exposure.Type = "LoadBalancer"
+			},
+			errors: []string{"spec.exposure.type"},
+		},
+		{
+			name: "malformed nginx sizes",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Nginx = hawtiov2.HawtioNginx{
+					ClientBodyBufferSize:       "256 kilobytes",
+					ProxyBuffers:               "128k",
+					SubrequestOutputBufferSize: "-10m",
+					MasterBurstSize:            "5k",
+				}
+			},
+			errors: []string{
+				"spec.nginx.clientBodyBufferSize",
+				"spec.nginx.proxyBuffers",
+				"spec.nginx.subrequestOutputBufferSize",
+				"spec.nginx.masterBurstSize",
+			},
+		},
+		{
+			name: "unknown log levels",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Logging = hawtiov2.HawtioLogging{
+					OnlineLogLevel:  "verbose",
+					GatewayLogLevel: "trace",
+					MaskIPAddresses: "yes",
+				}
+			},
+			errors: []string{
+				"spec.logging.onlineLogLevel",
+				"spec.logging.gatewayLogLevel",
+				"spec.logging.maskIPAddresses",
+			},
+		},
+		{
+			name: "expired client certificate expiration date",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Auth.ClientCertExpirationDate = &past
+			},
+			errors: []string{"spec.auth.clientCertExpirationDate"},
+		},
+		{
+			name: "valid pod disruption budget",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				minAvailable := intstr.FromString("50%")
+				hawtio.Spec.PodDisruptionBudget.MinAvailable = &minAvailable
+			},
+		},
+		{
+			name: "allowed source ranges",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8", "2001:db8::/32"}
+			},
+		},
+		{
+			name: "malformed allowed source ranges",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8", "10.0.0.1", " 192.168.0.0/16"}
+			},
+			errors: []string{
+				"spec.access.allowedSourceRanges[1]",
+				"spec.access.allowedSourceRanges[2]",
+			},
+		},
+		{
+			name: "allowed source ranges with the Gateway API",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Exposure.Type = hawtiov2.GatewayAPIHawtioExposureType
+				hawtio.Spec.HTTPRoute.ParentRef.Name = "gateway"
+				hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8"}
+			},
+			errors: []string{"spec.access.allowedSourceRanges"},
+		},
+		{
+			name: "valid network policy",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.NetworkPolicy = hawtiov2.HawtioNetworkPolicy{
+					Enabled:                    true,
+					IngressControllerNamespace: "traefik",
+					APIServerCIDRs:             []string{"10.0.0.1/32", "fd00::1/128"},
+				}
+			},
+		},
+		{
+			name: "invalid network policy ingress controller namespace",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.NetworkPolicy = hawtiov2.HawtioNetworkPolicy{
+					Enabled:                    true,
+					IngressControllerNamespace: "Ingress.Nginx",
+				}
+			},
+			errors: []string{
+				"spec.networkPolicy.ingressControllerNamespace",
+			},
+		},
+		{
+			name: "invalid network policy API server CIDRs",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.NetworkPolicy = hawtiov2.HawtioNetworkPolicy{
+					Enabled:        true,
+					APIServerCIDRs: []string{"10.0.0.1/32", "10.0.0.1"},
+				}
+			},
+			errors: []string{
+				"spec.networkPolicy.apiServerCIDRs[1]",
+			},
+		},
+		{
+			name: "invalid pod disruption budget",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				minAvailable := intstr.FromString("half")
+				maxUnavailable := intstr.FromInt32(-1)
+				hawtio.Spec.PodDisruptionBudget = hawtiov2.HawtioPodDisruptionBudget{
+					MinAvailable:   &minAvailable,
+					MaxUnavailable: &maxUnavailable,
+				}
+			},
+			errors: []string{
+				"spec.podDisruptionBudget.minAvailable",
+				"spec.podDisruptionBudget.maxUnavailable",
+			},
+		},
+		{
+			name: "valid autoscaling",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				minReplicas := int32(2)
+				hawtio.Spec.Autoscaling = hawtiov2.HawtioAutoscaling{
+					Enabled:     true,
+					MinReplicas: &minReplicas,
+					MaxReplicas: 5,
+				}
+			},
+		},
+		{
+			name: "autoscaling without max replicas",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.Autoscaling.Enabled = true
+			},
+			errors: []string{"spec.autoscaling.maxReplicas"},
+		},
+		{
+			name: "autoscaling with min replicas above max replicas",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				minReplicas := int32(3)
+				hawtio.Spec.Autoscaling = hawtiov2.HawtioAutoscaling{
+					Enabled:     true,
+					MinReplicas: &minReplicas,
+					MaxReplicas: 2,
+				}
+			},
+			errors: []string{"spec.autoscaling.maxReplicas"},
+		},
+		{
+			name: "valid pod template",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.PodTemplate = hawtiov2.HawtioPodTemplate{
+					Volumes:    []corev1.Volume{{Name: "plugins"}},
+					Containers: []corev1.Container{{Name: "log-shipper"}},
+				}
+			},
+		},
+		{
+			name: "pod template conflicting with managed volumes and containers",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.PodTemplate = hawtiov2.HawtioPodTemplate{
+					Volumes:    []corev1.Volume{{Name: "plugins"}, {Name: "hawtio-rbac"}},
+					Containers: []corev1.Container{{Name: "hawtio-online-container"}},
+				}
+			},
+			errors: []string{
+				"spec.podTemplate.volumes[1].name",
+				"spec.podTemplate.containers[0].name",
+			},
+		},
+		{
+			name: "pod template conflicting with managed environment variables and volume mounts",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.PodTemplate = hawtiov2.HawtioPodTemplate{
+					Online: hawtiov2.HawtioContainerTemplate{
+						Env: []corev1.EnvVar{{Name: "EXTRA"}, {Name: "HAWTIO_ONLINE_MODE"}, {Name: "EXTRA"}},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "plugins", MountPath: "/plugins"},
+							{Name: "plugins", MountPath: "/var/cache/nginx"},
+						},
+					},
+					Gateway: hawtiov2.HawtioContainerTemplate{
+						Env:          []corev1.EnvVar{{Name: "EXTRA"}, {Name: "HAWTIO_ONLINE_RBAC_ACL"}},
+						VolumeMounts: []corev1.VolumeMount{{Name: "plugins", MountPath: "/plugins"}, {Name: "plugins", MountPath: "/plugins"}},
+					},
+					Volumes: []corev1.Volume{{Name: "plugins"}},
+				}
+			},
+			errors: []string{
+				"spec.podTemplate.online.env[1].name",
+				"spec.podTemplate.online.env[2].name: Duplicate value",
+				"spec.podTemplate.online.volumeMounts[1].mountPath",
+				"spec.podTemplate.gateway.env[1].name",
+				"spec.podTemplate.gateway.volumeMounts[1].mountPath: Duplicate value",
+			},
+		},
+		{
+			name: "pod template with conflicting init container names",
+			mutate: func(hawtio *hawtiov2.Hawtio) {
+				hawtio.Spec.PodTemplate = hawtiov2.HawtioPodTemplate{
+					InitContainers: []corev1.Container{{Name: "hawtio-online-gateway-container"}, {Name: "install-plugins"}},
+					Containers:     []corev1.Container{{Name: "install-plugins"}},
+				}
+			},
+			errors: []string{
+				"spec.podTemplate.initContainers[0].name",
+				"spec.podTemplate.containers[0].name: Duplicate value",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hawtio := newTestHawtio()
+			tt.mutate(hawtio)
+
+			_, err := newTestValidator(t).ValidateCreate(context.TODO(), hawtio)
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, field := range tt.errors {
+				assert.Contains(t, err.Error(), field)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateUnchangedExpirationDate(t *testing.T) {
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	oldHawtio := newTestHawtio()
+	oldHawtio.Spec.Auth.ClientCertExpirationDate = &past
+
+	replicas := int32(2)
+	newHawtio := oldHawtio.DeepCopy()
+	newHawtio.Spec.Replicas = &replicas
+
+	_, err := newTestValidator(t).ValidateUpdate(context.TODO(), oldHawtio, newHawtio)
+	assert.NoError(t, err)
+}
+
+func TestValidateUpdateUnchangedVersion(t *testing.T) {
+	oldHawtio := newTestHawtio()
+	oldHawtio.Spec.Version = "1.0"
+
+	replicas := int32(2)
+	newHawtio := oldHawtio.DeepCopy()
+	newHawtio.Spec.Replicas = &replicas
+
+	validator := newTestValidator(t)
+	_, err := validator.ValidateUpdate(context.TODO(), oldHawtio, newHawtio)
+	assert.NoError(t, err)
+
+	newHawtio.Spec.Version = "1.1"
+	_, err = validator.ValidateUpdate(context.TODO(), oldHawtio, newHawtio)
+	assert.ErrorContains(t, err, "spec.version")
+}
+
+func TestValidateUpdateUnchangedGatewayAPIAccess(t *testing.T) {
+	oldHawtio := newTestHawtio()
+	oldHawtio.Spec.Exposure.Type = hawtiov2.GatewayAPIHawtioExposureType
+	oldHawtio.Spec.HTTPRoute.ParentRef.Name = "gateway"
+	oldHawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8"}
+
+	replicas := int32(2)
+	newHawtio := oldHawtio.DeepCopy()
+	newHawtio.Spec.Replicas = &replicas
+
+	validator := newTestValidator(t)
+	_, err := validator.ValidateUpdate(context.TODO(), oldHawtio, newHawtio)
+	assert.NoError(t, err)
+
+	oldHawtio.Spec.Access.AllowedSourceRanges = nil
+	_, err = validator.ValidateUpdate(context.TODO(), oldHawtio, newHawtio)
+	assert.ErrorContains(t, err, "spec.access.allowedSourceRanges")
+}
+
+func TestValidateAccessWarnings(t *testing.T) {
+	hawtio := newTestHawtio()
+	hawtio.Spec.Access.AllowedSourceRanges = []string{"10.0.0.0/8"}
+	hawtio.Spec.Service.Type = corev1.ServiceTypeNodePort
+	hawtio.Spec.Exposure.Type = hawtiov2.IngressHawtioExposureType
+	hawtio.Spec.Ingress.ClassName = "traefik"
+
+	warnings, err := newTestValidator(t).ValidateCreate(context.TODO(), hawtio)
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "NodePort")
+	assert.Contains(t, warnings[1], "traefik")
+
+	hawtio.Spec.Service.Type = corev1.ServiceTypeLoadBalancer
+	hawtio.Spec.Ingress.ClassName = "nginx"
+	warnings, err = newTestValidator(t).ValidateCreate(context.TODO(), hawtio)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestValidateWarnings(t *testing.T) {
+	hawtio := newTestHawtio()
+	hawtio.Spec.Auth.ClientCertCheckSchedule = "* */12 * * *"
+	hawtio.Spec.Config.About.AdditionalInfo = "Some info"
+	hawtio.Spec.RBAC.ConfigMap = "missing-rbac"
+
+	warnings, err := newTestValidator(t).ValidateCreate(context.TODO(), hawtio)
+	require.NoError(t, err)
+	require.Len(t, warnings, 3)
+	assert.Contains(t, warnings[0], "spec.auth.clientCertCheckSchedule")
+	assert.Contains(t, warnings[1], "spec.config.about.additionalInfo")
+	assert.Contains(t, warnings[2], "not found")
+}
+
+func TestValidateRBACConfigMapWarnings(t *testing.T) {
+	hawtio := newTestHawtio()
+	hawtio.Spec.RBAC.ConfigMap = "rbac"
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rbac",
+			Namespace: hawtio.Namespace,
+		},
+	}
+
+	warnings, err := newTestValidator(t, configMap).ValidateCreate(context.TODO(), hawtio)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], resources.RBACConfigMapKey)
+
+	configMap.Data = map[string]string{resources.RBACConfigMapKey: "{}"}
+
+	warnings, err = newTestValidator(t, configMap).ValidateCreate(context.TODO(), hawtio)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
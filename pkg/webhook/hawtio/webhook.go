@@ -0,0 +1,16 @@
+package hawtio
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	hawtiov2 "github.com/hawtio/hawtio-operator/pkg/apis/hawtio/v2"
+)
+
+// Add registers the Hawtio admission and conversion webhooks with the Manager's webhook server.
+func Add(mgr manager.Manager) error {
+	return builder.WebhookManagedBy(mgr, &hawtiov2.Hawtio{}).
+		WithDefaulter(NewDefaulter()).
+		WithValidator(NewValidator(mgr.GetAPIReader())).
+		Complete()
+}